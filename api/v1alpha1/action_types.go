@@ -0,0 +1,139 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type ConfigMapRef struct {
+	Name string `json:"name"`
+	Key  string `json:"key"`
+}
+
+// ActionTrigger is the trigger an action is executed by
+type ActionTrigger struct {
+	// The ID of the trigger
+	// +kubebuilder:validation:Enum:={"post-login","credentials-exchange","pre-user-registration","post-user-registration","post-change-password","send-phone-message","password-reset-post-challenge"}
+	Id string `json:"id"`
+
+	// The version of the trigger, e.g. v3. Defaults to the latest version
+	// of the trigger
+	Version string `json:"version,omitempty"`
+}
+
+// ActionDependency is an npm package an action depends on
+type ActionDependency struct {
+	// The name of the package
+	// +kubebuilder:validation:MinLength:=1
+	Name string `json:"name"`
+
+	// The version of the package
+	// +kubebuilder:validation:MinLength:=1
+	Version string `json:"version"`
+}
+
+// ActionSecret is a secret made available to an action via event.secrets
+type ActionSecret struct {
+	// The name of the secret in the action
+	// +kubebuilder:validation:MinLength:=1
+	Name string `json:"name"`
+
+	// The Secret key holding the value of the secret
+	SecretRef SecretRef `json:"secretRef"`
+}
+
+// ActionSpec defines the desired state of Action
+type ActionSpec struct {
+	// The Auth0Tenant the resource is managed in. Defaults to the default
+	// tenant of the operator. Cannot be changed once set
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="tenantRef is immutable"
+	TenantRef *TenantReference `json:"tenantRef,omitempty"`
+
+	// The name of the action
+	// +kubebuilder:validation:MinLength:=1
+	Name string `json:"name"`
+
+	// The trigger the action is executed by
+	Trigger ActionTrigger `json:"trigger"`
+
+	// The ConfigMap key holding the JavaScript source of the action
+	CodeRef ConfigMapRef `json:"codeRef"`
+
+	// The Node runtime of the action
+	// +kubebuilder:default:=node18
+	// +kubebuilder:validation:Enum:={"node16","node18"}
+	Runtime string `json:"runtime,omitempty"`
+
+	// The npm packages the action depends on
+	Dependencies []ActionDependency `json:"dependencies,omitempty"`
+
+	// The secrets made available to the action
+	Secrets []ActionSecret `json:"secrets,omitempty"`
+
+	// Whether changes to the action are deployed. Actions that aren't
+	// deployed are saved as drafts and can't be bound to a trigger
+	Deploy bool `json:"deploy,omitempty"`
+}
+
+// ActionStatus defines the observed state of Action
+type ActionStatus struct {
+	// The Auth0 ID of this action
+	Auth0Id string `json:"auth0Id,omitempty"`
+
+	// The number of the deployed version of the action
+	DeployedVersion int `json:"deployedVersion,omitempty"`
+
+	// A hash of the versions of the Secrets whose values were last sent to
+	// Auth0, used to detect changes as Auth0 doesn't return secret values
+	SecretsHash string `json:"secretsHash,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// Action is the Schema for the actions API
+type Action struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ActionSpec   `json:"spec,omitempty"`
+	Status ActionStatus `json:"status,omitempty"`
+}
+
+// IsBeingDeleted returns true if the Action is being deleted (i.e. has a deletion timestamp)
+func (a *Action) IsBeingDeleted() bool {
+	return a.GetDeletionTimestamp() != nil
+}
+
+// Auth0Id returns the Auth0 ID of the Action
+func (a *Action) Auth0Id() string {
+	return a.Status.Auth0Id
+}
+
+//+kubebuilder:object:root=true
+
+// ActionList contains a list of Action
+type ActionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Action `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Action{}, &ActionList{})
+}
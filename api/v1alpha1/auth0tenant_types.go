@@ -0,0 +1,132 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// Auth0TenantKind is the kind of cluster scoped tenants
+	Auth0TenantKind = "Auth0Tenant"
+
+	// NamespacedAuth0TenantKind is the kind of tenants that can only be
+	// referenced from their own namespace
+	NamespacedAuth0TenantKind = "NamespacedAuth0Tenant"
+)
+
+// AllNamespaces allows resources in any namespace to use an Auth0Tenant
+// when listed in its allowed namespaces
+const AllNamespaces = "*"
+
+// TenantReference references the Auth0Tenant or NamespacedAuth0Tenant a
+// resource is managed in
+type TenantReference struct {
+	// The kind of the tenant. A NamespacedAuth0Tenant must be in the same
+	// namespace as the resource. Defaults to Auth0Tenant
+	// +kubebuilder:validation:Enum:={"Auth0Tenant","NamespacedAuth0Tenant"}
+	Kind string `json:"kind,omitempty"`
+
+	// The name of the tenant
+	// +kubebuilder:validation:MinLength:=1
+	Name string `json:"name"`
+}
+
+// NamespacedSecretRef references a key of a secret in a namespace
+type NamespacedSecretRef struct {
+	// The namespace of the secret
+	// +kubebuilder:validation:MinLength:=1
+	Namespace string `json:"namespace"`
+
+	// The name of the secret
+	// +kubebuilder:validation:MinLength:=1
+	Name string `json:"name"`
+
+	// The key of the value in the secret
+	// +kubebuilder:validation:MinLength:=1
+	Key string `json:"key"`
+}
+
+// Auth0TenantSpec defines the desired state of Auth0Tenant
+// +kubebuilder:validation:XValidation:rule="[has(self.clientSecretRef), has(self.privateKeyRef), has(self.privateKeyFile)].filter(x, x).size() == 1",message="exactly one of clientSecretRef, privateKeyRef or privateKeyFile must be set"
+type Auth0TenantSpec struct {
+	// The domain of the tenant, e.g. example.eu.auth0.com
+	// +kubebuilder:validation:MinLength:=1
+	Domain string `json:"domain"`
+
+	// The client ID of the machine to machine application the operator
+	// uses to manage the tenant
+	// +kubebuilder:validation:MinLength:=1
+	ClientId string `json:"clientId"`
+
+	// The secret holding the client secret of the machine to machine
+	// application
+	ClientSecretRef *NamespacedSecretRef `json:"clientSecretRef,omitempty"`
+
+	// The secret holding the PEM encoded RSA private key the machine to
+	// machine application authenticates with (private_key_jwt)
+	PrivateKeyRef *NamespacedSecretRef `json:"privateKeyRef,omitempty"`
+
+	// The path of a file in the operator's container holding the PEM
+	// encoded RSA private key the machine to machine application
+	// authenticates with (private_key_jwt)
+	PrivateKeyFile string `json:"privateKeyFile,omitempty"`
+
+	// The namespaces whose resources may be managed in the tenant, or "*"
+	// for any namespace. No namespace may use the tenant if empty
+	// +listType=set
+	AllowedNamespaces []string `json:"allowedNamespaces,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:printcolumn:name="Domain",type=string,JSONPath=`.spec.domain`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// Auth0Tenant is the Schema for the auth0tenants API. It holds the
+// credentials used to manage an Auth0 tenant
+type Auth0Tenant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec Auth0TenantSpec `json:"spec,omitempty"`
+}
+
+// AllowsNamespace returns true if resources in the namespace may be managed
+// in the Auth0Tenant. Namespaces must be allowed explicitly
+func (t *Auth0Tenant) AllowsNamespace(namespace string) bool {
+	for _, allowed := range t.Spec.AllowedNamespaces {
+		if allowed == AllNamespaces || allowed == namespace {
+			return true
+		}
+	}
+
+	return false
+}
+
+//+kubebuilder:object:root=true
+
+// Auth0TenantList contains a list of Auth0Tenant
+type Auth0TenantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Auth0Tenant `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Auth0Tenant{}, &Auth0TenantList{})
+}
@@ -17,17 +17,82 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"time"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
+// AdoptClientAnnotation can be set to the Auth0 ID of an existing client to
+// adopt it instead of creating a new one. spec.auth0Id takes precedence
+const AdoptClientAnnotation = "auth0.gracey.io/adopt-client-id"
+
+// RotateSecretAnnotation requests a rotation of the client secret whenever
+// its value changes, e.g. to the current time
+const RotateSecretAnnotation = "auth0.gracey.io/rotate-secret"
+
+// OutputKeysAnnotation records the keys of an output secret or config map
+// that were written by the operator, so that only those are removed once
+// they're no longer written
+const OutputKeysAnnotation = "auth0.gracey.io/output-keys"
+
+// DefaultRotationOverlap is how long the previous client secret is kept in
+// the output secret after a rotation by default
+const DefaultRotationOverlap = 24 * time.Hour
+
+const (
+	// ConditionTypeReady indicates the Auth0 resource is in the desired state
+	// and, for Clients, their outputs have been written, if requested
+	ConditionTypeReady = "Ready"
+
+	// ConditionTypeSynced indicates the spec was applied to Auth0 by the
+	// last reconcile
+	ConditionTypeSynced = "Synced"
+
+	// ConditionTypeSecretOutputReady indicates the client secret was written
+	// to the output secret. Only set if an output secret is requested
+	ConditionTypeSecretOutputReady = "SecretOutputReady"
+
+	// ConditionTypeConfigMapOutputReady indicates the output config map was
+	// written. Only set if an output config map is requested
+	ConditionTypeConfigMapOutputReady = "ConfigMapOutputReady"
+)
+
+// OwnerMetadataKey is the client metadata key of Auth0 clients that holds the
+// UID of the Client that manages them
+const OwnerMetadataKey = "auth0_operator_uid"
+
+// MaxMetadataKeys is the number of client metadata keys Auth0 allows
+const MaxMetadataKeys = 10
+
+const (
+	// DeletionPolicyDelete deletes the Auth0 client when the Client is deleted
+	DeletionPolicyDelete = "Delete"
+
+	// DeletionPolicyOrphan leaves the Auth0 client in place when the Client
+	// is deleted
+	DeletionPolicyOrphan = "Orphan"
+)
+
+const (
+	// NotFoundPolicyRecreate creates a new Auth0 client when the Auth0
+	// client of the Client no longer exists
+	NotFoundPolicyRecreate = "Recreate"
+
+	// NotFoundPolicyReport reports that the Auth0 client of the Client no
+	// longer exists in the Synced condition
+	NotFoundPolicyReport = "Report"
+)
+
 type SecretRef struct {
 	Name string `json:"name"`
 	Key  string `json:"key"`
 }
 
+// ClientSecret configures the secret of a client and where it is output to
+// +kubebuilder:validation:XValidation:rule="!has(self.rotationSchedule) || (!has(self.literal) && (!has(self.secretRef) || size(self.secretRef.name) == 0))",message="rotationSchedule can't be set with literal or secretRef"
 type ClientSecret struct {
 	// +kubebuilder:validation:MinLength:=48
 	Literal string `json:"literal,omitempty"`
@@ -35,10 +100,90 @@ type ClientSecret struct {
 	SecretRef SecretRef `json:"secretRef,omitempty"`
 
 	OutputSecretRef SecretRef `json:"outputSecretRef,omitempty"`
+
+	// A secret to store a client secret generated by the operator in when
+	// neither literal nor secretRef is set, rather than letting Auth0
+	// generate one. The secret is created if it doesn't exist and is owned
+	// by the Client. If it does exist its value is used, so it can be backed
+	// up and restored
+	GeneratedSecretRef *SecretRef `json:"generatedSecretRef,omitempty"`
+
+	// The keys of the output secret to write details of the client to,
+	// alongside the client secret
+	OutputKeys *OutputSecretKeys `json:"outputKeys,omitempty"`
+
+	// The audience of the API the client calls, written to the output secret
+	// under outputKeys.audience
+	OutputAudience string `json:"outputAudience,omitempty"`
+
+	// Go text/templates rendered into the output secret, keyed by the key
+	// they are written to. Templates are rendered with the details of the
+	// client: .ClientId, .ClientSecret, .Domain, .Issuer, .TokenEndpoint,
+	// .AuthorizeEndpoint, .JwksUri and .Audience
+	OutputTemplates map[string]string `json:"outputTemplates,omitempty"`
+
+	// How often the client secret is rotated, e.g. 2160h for every 90 days.
+	// Rotations can also be requested with the auth0.gracey.io/rotate-secret
+	// annotation. Only generated secrets are rotated, so this can't be set
+	// with literal or secretRef
+	RotationSchedule *metav1.Duration `json:"rotationSchedule,omitempty"`
+
+	// How long the previous client secret is kept in the output secret after
+	// a rotation, so it can still be used while the new one is rolled out.
+	// Defaults to 24h
+	RotationOverlap *metav1.Duration `json:"rotationOverlap,omitempty"`
+}
+
+// OutputConfigMap is a config map rendered with the non-sensitive details of
+// a client
+type OutputConfigMap struct {
+	// The name of the config map
+	Name string `json:"name"`
+
+	// Go text/templates rendered into the config map, keyed by the key they
+	// are written to. Templates are rendered with the same details as output
+	// secret templates, except .ClientSecret which is always empty
+	// +kubebuilder:validation:MinProperties:=1
+	Templates map[string]string `json:"templates"`
+}
+
+// OutputSecretKeys are the keys of the output secret that details of the
+// client are written to. Details without a key aren't written
+type OutputSecretKeys struct {
+	// The key of the client ID, e.g. client_id
+	ClientId string `json:"clientId,omitempty"`
+
+	// The key of the tenant domain, e.g. example.eu.auth0.com
+	Domain string `json:"domain,omitempty"`
+
+	// The key of the issuer URL, e.g. https://example.eu.auth0.com/
+	Issuer string `json:"issuer,omitempty"`
+
+	// The key of the token endpoint
+	TokenEndpoint string `json:"tokenEndpoint,omitempty"`
+
+	// The key of the authorization endpoint
+	AuthorizeEndpoint string `json:"authorizeEndpoint,omitempty"`
+
+	// The key of the JSON Web Key Set URL
+	JwksUri string `json:"jwksUri,omitempty"`
+
+	// The key of clientSecret.outputAudience
+	Audience string `json:"audience,omitempty"`
+
+	// The key of the client secret replaced by the last rotation, which is
+	// only written during the rotation overlap. Defaults to the key of
+	// outputSecretRef suffixed with -previous
+	PreviousClientSecret string `json:"previousClientSecret,omitempty"`
 }
 
 // ClientSpec defines the desired state of Client
 type ClientSpec struct {
+	// The Auth0Tenant the resource is managed in. Defaults to the default
+	// tenant of the operator. Cannot be changed once set
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="tenantRef is immutable"
+	TenantRef *TenantReference `json:"tenantRef,omitempty"`
+
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
 
@@ -55,11 +200,42 @@ type ClientSpec struct {
 	// +kubebuilder:validation:Enum:={"spa","native","regular","non_interactive"}
 	Type string `json:"type,omitempty"`
 
-	// The metadata associated with this client
+	// The metadata associated with this client. Auth0 allows 10 keys. If
+	// fewer are set, another records the Client that owns the Auth0 client
 	// +kubebuilder:validation:MaxProperties:=10
+	// +kubebuilder:validation:XValidation:rule="!('auth0_operator_uid' in self)",message="auth0_operator_uid is reserved for the operator"
 	Metadata map[string]string `json:"metadata,omitempty"`
 
 	ClientSecret ClientSecret `json:"clientSecret,omitempty"`
+
+	// A config map to write non-sensitive details of the client to, e.g.
+	// for frontend configuration
+	OutputConfigMap *OutputConfigMap `json:"outputConfigMap,omitempty"`
+
+	// The Auth0 ID of an existing client to adopt instead of creating a new
+	// one. Only used until the client has been adopted. The management client
+	// of the tenant and clients managed by other Clients can't be adopted.
+	// The description, callbackUrls and metadata of adopted clients are left
+	// as they are in Auth0 while they're unset
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="auth0Id is immutable"
+	Auth0Id string `json:"auth0Id,omitempty"`
+
+	// What happens to the Auth0 client when the Client is deleted. Defaults
+	// to Orphan for adopted clients, and otherwise to the deletion policy the
+	// controller is configured with
+	// +kubebuilder:validation:Enum:={"Delete","Orphan"}
+	DeletionPolicy string `json:"deletionPolicy,omitempty"`
+
+	// How often the Auth0 client is synced with the spec, reverting changes
+	// made outside of the operator. Defaults to the sync period the
+	// controller is configured with. 0s disables periodic syncs
+	SyncInterval *metav1.Duration `json:"syncInterval,omitempty"`
+
+	// What happens when the Auth0 client no longer exists, e.g. because it
+	// was deleted in the dashboard. Adopted clients are never recreated.
+	// Defaults to Recreate
+	// +kubebuilder:validation:Enum:={"Recreate","Report"}
+	NotFoundPolicy string `json:"notFoundPolicy,omitempty"`
 }
 
 // ClientStatus defines the observed state of Client
@@ -69,10 +245,44 @@ type ClientStatus struct {
 
 	// The Auth0 ID of this client
 	Auth0Id string `json:"auth0Id,omitempty"`
+
+	// Whether a client may have been created in Auth0 without its ID being
+	// recorded, in which case it's looked for before creating another
+	PendingCreate bool `json:"pendingCreate,omitempty"`
+
+	// The generation of the Client last successfully synced with Auth0
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// When the Client was last successfully synced with Auth0
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// The error of the last sync, if it failed
+	LastError string `json:"lastError,omitempty"`
+
+	// The fields of the Auth0 client that were changed outside of the
+	// operator and reverted by the last sync
+	DriftedFields []string `json:"driftedFields,omitempty"`
+
+	// When the client secret was last rotated
+	LastRotated *metav1.Time `json:"lastRotated,omitempty"`
+
+	// The value of the auth0.gracey.io/rotate-secret annotation when the
+	// client secret was last rotated
+	LastRotationRequest string `json:"lastRotationRequest,omitempty"`
+
+	// The latest observations of the Client's state
+	// +listType=map
+	// +listMapKey=type
+	// +patchStrategy=merge
+	// +patchMergeKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Auth0 ID",type=string,JSONPath=`.status.auth0Id`
+//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // Client is the Schema for the clients API
 type Client struct {
@@ -88,16 +298,77 @@ func (c *Client) IsBeingDeleted() bool {
 	return c.GetDeletionTimestamp() != nil
 }
 
+// StatusConditions returns the conditions of the Client for updating
+func (c *Client) StatusConditions() *[]metav1.Condition {
+	return &c.Status.Conditions
+}
+
 // Auth0Id returns the Auth0 ID of the Client
 func (c *Client) Auth0Id() string {
 	return c.Status.Auth0Id
 }
 
+// AdoptAuth0Id returns the Auth0 ID of an existing client the Client should
+// adopt, or an empty string if a new client should be created
+func (c *Client) AdoptAuth0Id() string {
+	if c.Spec.Auth0Id != "" {
+		return c.Spec.Auth0Id
+	}
+
+	return c.GetAnnotations()[AdoptClientAnnotation]
+}
+
 // ShouldOutputSecret returns true if the Client should create a k8s secret
 func (c *Client) ShouldOutputSecret() bool {
 	return c.Spec.ClientSecret.OutputSecretRef.Name != ""
 }
 
+// GeneratesSecret returns true if the Client's client secret is generated by
+// the operator and stored in its generated secret
+func (c *Client) GeneratesSecret() bool {
+	secret := c.Spec.ClientSecret
+	return secret.GeneratedSecretRef != nil && secret.SecretRef.Name == "" && secret.Literal == ""
+}
+
+// CanRotateSecret returns true if the client secret is generated, by Auth0 or
+// the operator, rather than set by literal or secretRef
+func (c *Client) CanRotateSecret() bool {
+	return c.Spec.ClientSecret.Literal == "" && c.Spec.ClientSecret.SecretRef.Name == ""
+}
+
+// RotationRequested returns true if the auth0.gracey.io/rotate-secret
+// annotation has changed since the client secret was last rotated
+func (c *Client) RotationRequested() bool {
+	request := c.GetAnnotations()[RotateSecretAnnotation]
+	return request != "" && request != c.Status.LastRotationRequest
+}
+
+// RotationOverlap returns how long the previous client secret is kept in the
+// output secret after a rotation
+func (c *Client) RotationOverlap() time.Duration {
+	if c.Spec.ClientSecret.RotationOverlap != nil {
+		return c.Spec.ClientSecret.RotationOverlap.Duration
+	}
+
+	return DefaultRotationOverlap
+}
+
+// PreviousSecretKey returns the key of the output secret that the client
+// secret replaced by the last rotation is written to
+func (c *Client) PreviousSecretKey() string {
+	if keys := c.Spec.ClientSecret.OutputKeys; keys != nil && keys.PreviousClientSecret != "" {
+		return keys.PreviousClientSecret
+	}
+
+	return c.Spec.ClientSecret.OutputSecretRef.Key + "-previous"
+}
+
+// ShouldOutputConfigMap returns true if the Client should create a k8s
+// config map
+func (c *Client) ShouldOutputConfigMap() bool {
+	return c.Spec.OutputConfigMap != nil
+}
+
 //+kubebuilder:object:root=true
 
 // ClientList contains a list of Client
@@ -0,0 +1,118 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClientReference refers to an Auth0 client, either via a Client in the
+// same namespace or directly by its Auth0 ID
+// +kubebuilder:validation:XValidation:rule="has(self.name) != has(self.auth0Id)",message="exactly one of name or auth0Id must be set"
+type ClientReference struct {
+	// The name of a Client in the same namespace
+	Name string `json:"name,omitempty"`
+
+	// The Auth0 ID of a client that isn't managed by a Client
+	Auth0Id string `json:"auth0Id,omitempty"`
+}
+
+// ClientGrantSpec defines the desired state of ClientGrant
+type ClientGrantSpec struct {
+	// The Auth0Tenant the resource is managed in. Defaults to the default
+	// tenant of the operator. Cannot be changed once set
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="tenantRef is immutable"
+	TenantRef *TenantReference `json:"tenantRef,omitempty"`
+
+	// The client being granted access to the API.
+	// Auth0 doesn't allow this to be changed once created
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="clientRef is immutable"
+	ClientRef ClientReference `json:"clientRef"`
+
+	// The audience (identifier) of the API the client is granted access to.
+	// Auth0 doesn't allow this to be changed once created
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="audience is immutable"
+	Audience string `json:"audience"`
+
+	// The scopes of the API granted to the client
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+// ClientGrantStatus defines the observed state of ClientGrant
+type ClientGrantStatus struct {
+	// The Auth0 ID of this client grant
+	Auth0Id string `json:"auth0Id,omitempty"`
+
+	// The Auth0 ID of the client the grant was created for
+	ClientId string `json:"clientId,omitempty"`
+
+	// Whether a client grant may have been created in Auth0 without its ID
+	// being recorded, in which case it's looked up by its client and
+	// audience before creating another
+	PendingCreate bool `json:"pendingCreate,omitempty"`
+
+	// The latest observations of the ClientGrant's state
+	// +listType=map
+	// +listMapKey=type
+	// +patchStrategy=merge
+	// +patchMergeKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Auth0 ID",type=string,JSONPath=`.status.auth0Id`
+//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// ClientGrant is the Schema for the clientgrants API
+type ClientGrant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClientGrantSpec   `json:"spec,omitempty"`
+	Status ClientGrantStatus `json:"status,omitempty"`
+}
+
+// IsBeingDeleted returns true if the ClientGrant is being deleted (i.e. has a deletion timestamp)
+func (g *ClientGrant) IsBeingDeleted() bool {
+	return g.GetDeletionTimestamp() != nil
+}
+
+// StatusConditions returns the conditions of the ClientGrant for updating
+func (g *ClientGrant) StatusConditions() *[]metav1.Condition {
+	return &g.Status.Conditions
+}
+
+// Auth0Id returns the Auth0 ID of the ClientGrant
+func (g *ClientGrant) Auth0Id() string {
+	return g.Status.Auth0Id
+}
+
+//+kubebuilder:object:root=true
+
+// ClientGrantList contains a list of ClientGrant
+type ClientGrantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClientGrant `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClientGrant{}, &ClientGrantList{})
+}
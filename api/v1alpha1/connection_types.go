@@ -0,0 +1,386 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type PasswordComplexityOptions struct {
+	// The minimum length of passwords
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=128
+	MinLength int `json:"minLength"`
+}
+
+type PasswordHistory struct {
+	// Whether users are prevented from reusing previous passwords
+	Enable bool `json:"enable"`
+
+	// The number of previous passwords that can't be reused
+	// +kubebuilder:validation:Minimum:=0
+	// +kubebuilder:validation:Maximum:=24
+	Size int `json:"size,omitempty"`
+}
+
+type PasswordDictionary struct {
+	// Whether passwords found in the default dictionary are disallowed
+	Enable bool `json:"enable"`
+
+	// Additional words that are disallowed as passwords
+	Dictionary []string `json:"dictionary,omitempty"`
+}
+
+type PasswordNoPersonalInfo struct {
+	// Whether passwords containing the user's personal info are disallowed
+	Enable bool `json:"enable"`
+}
+
+type UsernameLength struct {
+	// The minimum length of usernames
+	// +kubebuilder:validation:Minimum:=1
+	Min int `json:"min"`
+
+	// The maximum length of usernames
+	// +kubebuilder:validation:Maximum:=128
+	Max int `json:"max"`
+}
+
+// DatabaseConnection defines the options of an auth0 (database) connection
+type DatabaseConnection struct {
+	// The strength of passwords users are required to use
+	// +kubebuilder:validation:Enum:={"none","low","fair","good","excellent"}
+	PasswordPolicy string `json:"passwordPolicy,omitempty"`
+
+	PasswordComplexityOptions *PasswordComplexityOptions `json:"passwordComplexityOptions,omitempty"`
+
+	PasswordHistory *PasswordHistory `json:"passwordHistory,omitempty"`
+
+	PasswordDictionary *PasswordDictionary `json:"passwordDictionary,omitempty"`
+
+	PasswordNoPersonalInfo *PasswordNoPersonalInfo `json:"passwordNoPersonalInfo,omitempty"`
+
+	// Whether users must supply a username in addition to their email
+	RequiresUsername *bool `json:"requiresUsername,omitempty"`
+
+	// The allowed length of usernames when requiresUsername is set
+	UsernameLength *UsernameLength `json:"usernameLength,omitempty"`
+
+	// Whether users are prevented from signing up
+	DisableSignup *bool `json:"disableSignup,omitempty"`
+
+	// Whether brute force protection is enabled
+	BruteForceProtection *bool `json:"bruteForceProtection,omitempty"`
+}
+
+// OIDCConnection defines the options of an oidc connection
+type OIDCConnection struct {
+	// The client ID of the application registered with the identity provider
+	ClientId string `json:"clientId"`
+
+	// The client secret of the application registered with the identity
+	// provider. Required for back channel connections
+	ClientSecretRef *SecretRef `json:"clientSecretRef,omitempty"`
+
+	// The OpenID Connect discovery URL of the identity provider
+	DiscoveryUrl string `json:"discoveryUrl,omitempty"`
+
+	// The issuer of the identity provider. Discovered if not set
+	Issuer string `json:"issuer,omitempty"`
+
+	// The authorization endpoint of the identity provider. Discovered if not set
+	AuthorizationEndpoint string `json:"authorizationEndpoint,omitempty"`
+
+	// The token endpoint of the identity provider. Discovered if not set
+	TokenEndpoint string `json:"tokenEndpoint,omitempty"`
+
+	// The userinfo endpoint of the identity provider. Discovered if not set
+	UserInfoEndpoint string `json:"userInfoEndpoint,omitempty"`
+
+	// The JWKS URI of the identity provider. Discovered if not set
+	JwksUri string `json:"jwksUri,omitempty"`
+
+	// The channel used to communicate with the identity provider
+	// +kubebuilder:validation:Enum:={"back_channel","front_channel"}
+	Type string `json:"type,omitempty"`
+
+	// The scopes to request from the identity provider
+	Scopes []string `json:"scopes,omitempty"`
+
+	// The email domains used for home realm discovery
+	DomainAliases []string `json:"domainAliases,omitempty"`
+
+	// The URL of the logo shown on the login button
+	LogoUrl string `json:"logoUrl,omitempty"`
+}
+
+// SAMLConnection defines the options of a samlp connection
+type SAMLConnection struct {
+	// The single sign on URL of the identity provider
+	SignInEndpoint string `json:"signInEndpoint,omitempty"`
+
+	// The single logout URL of the identity provider
+	SignOutEndpoint string `json:"signOutEndpoint,omitempty"`
+
+	// Whether single logout is disabled
+	DisableSignOut *bool `json:"disableSignOut,omitempty"`
+
+	// The X.509 signing certificate of the identity provider in PEM format
+	SigningCertRef SecretRef `json:"signingCertRef"`
+
+	// Whether SAML requests are signed
+	SignSAMLRequest *bool `json:"signSamlRequest,omitempty"`
+
+	// The algorithm used to sign SAML requests
+	// +kubebuilder:validation:Enum:={"rsa-sha256","rsa-sha1"}
+	SignatureAlgorithm string `json:"signatureAlgorithm,omitempty"`
+
+	// The algorithm used to digest SAML requests
+	// +kubebuilder:validation:Enum:={"sha256","sha1"}
+	DigestAlgorithm string `json:"digestAlgorithm,omitempty"`
+
+	// The binding used to send SAML requests
+	// +kubebuilder:validation:Enum:={"urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST","urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect"}
+	ProtocolBinding string `json:"protocolBinding,omitempty"`
+
+	// The SAML attribute used as the user ID
+	UserIdAttribute string `json:"userIdAttribute,omitempty"`
+
+	// Mappings of Auth0 user profile attributes to SAML attributes
+	FieldsMap map[string]string `json:"fieldsMap,omitempty"`
+
+	// The email domains used for home realm discovery
+	DomainAliases []string `json:"domainAliases,omitempty"`
+
+	// The URL of the logo shown on the login button
+	LogoUrl string `json:"logoUrl,omitempty"`
+}
+
+// AzureADConnection defines the options of a waad (Azure AD) connection
+type AzureADConnection struct {
+	// The client ID of the application registered in Azure AD
+	ClientId string `json:"clientId"`
+
+	// The client secret of the application registered in Azure AD
+	ClientSecretRef SecretRef `json:"clientSecretRef"`
+
+	// The Azure AD domain, e.g. contoso.onmicrosoft.com
+	Domain string `json:"domain"`
+
+	// The protocol used to communicate with Azure AD
+	// +kubebuilder:validation:Enum:={"openid-connect","ws-federation"}
+	Protocol string `json:"protocol,omitempty"`
+
+	// The API used to retrieve user profiles
+	// +kubebuilder:validation:Enum:={"microsoft-identity-platform-v2.0","azure-active-directory-v1.0"}
+	IdentityApi string `json:"identityApi,omitempty"`
+
+	// Whether users from any Azure AD tenant can log in
+	UseCommonEndpoint *bool `json:"useCommonEndpoint,omitempty"`
+
+	// Whether the basic profile of users is retrieved
+	BasicProfile *bool `json:"basicProfile,omitempty"`
+
+	// Whether the extended profile of users is retrieved
+	ExtendedProfile *bool `json:"extendedProfile,omitempty"`
+
+	// Whether the groups of users are retrieved
+	Groups *bool `json:"groups,omitempty"`
+
+	// Whether Auth0 can call the Azure AD users API
+	EnableUsersApi *bool `json:"enableUsersApi,omitempty"`
+
+	// The email domains used for home realm discovery
+	DomainAliases []string `json:"domainAliases,omitempty"`
+
+	// The URL of the logo shown on the login button
+	LogoUrl string `json:"logoUrl,omitempty"`
+}
+
+// GoogleWorkspaceConnection defines the options of a google-apps connection
+type GoogleWorkspaceConnection struct {
+	// The client ID of the application registered with Google
+	ClientId string `json:"clientId"`
+
+	// The client secret of the application registered with Google
+	ClientSecretRef SecretRef `json:"clientSecretRef"`
+
+	// The Google Workspace domain, e.g. example.com
+	Domain string `json:"domain"`
+
+	// Whether the basic profile of users is retrieved
+	BasicProfile *bool `json:"basicProfile,omitempty"`
+
+	// Whether the extended profile of users is retrieved
+	ExtendedProfile *bool `json:"extendedProfile,omitempty"`
+
+	// Whether the groups of users are retrieved
+	Groups *bool `json:"groups,omitempty"`
+
+	// Whether Auth0 can call the Google Workspace users API
+	EnableUsersApi *bool `json:"enableUsersApi,omitempty"`
+
+	// The email domains used for home realm discovery
+	DomainAliases []string `json:"domainAliases,omitempty"`
+
+	// The URL of the logo shown on the login button
+	LogoUrl string `json:"logoUrl,omitempty"`
+}
+
+// SocialConnection defines the options of a google-oauth2, github, apple or
+// windowslive connection
+type SocialConnection struct {
+	// The client ID of the application registered with the provider.
+	// For apple this is the services ID
+	ClientId string `json:"clientId"`
+
+	// The client secret of the application registered with the provider.
+	// For apple this is the private key used to sign client secrets
+	ClientSecretRef SecretRef `json:"clientSecretRef"`
+
+	// The Apple team ID. Required for, and only used by, apple
+	TeamId string `json:"teamId,omitempty"`
+
+	// The ID of the Apple signing key. Required for, and only used by, apple
+	KeyId string `json:"keyId,omitempty"`
+
+	// The scopes (attributes and permissions) to request from the provider,
+	// e.g. email and profile. Scopes not listed are disabled
+	Scopes []string `json:"scopes,omitempty"`
+
+	// When the root attributes of users are updated from the provider
+	// +kubebuilder:validation:Enum:={"on_each_login","on_first_login"}
+	SetUserRootAttributes string `json:"setUserRootAttributes,omitempty"`
+
+	// The user attributes that aren't stored by Auth0
+	NonPersistentAttributes []string `json:"nonPersistentAttributes,omitempty"`
+}
+
+// ConnectionSpec defines the desired state of Connection
+// +kubebuilder:validation:XValidation:rule="self.strategy == 'auth0' || !has(self.database)",message="database can only be set for the auth0 strategy"
+// +kubebuilder:validation:XValidation:rule="has(self.oidc) == (self.strategy == 'oidc')",message="oidc must be set if and only if strategy is oidc"
+// +kubebuilder:validation:XValidation:rule="has(self.saml) == (self.strategy == 'samlp')",message="saml must be set if and only if strategy is samlp"
+// +kubebuilder:validation:XValidation:rule="has(self.azureAd) == (self.strategy == 'waad')",message="azureAd must be set if and only if strategy is waad"
+// +kubebuilder:validation:XValidation:rule="has(self.googleWorkspace) == (self.strategy == 'google-apps')",message="googleWorkspace must be set if and only if strategy is google-apps"
+// +kubebuilder:validation:XValidation:rule="has(self.social) == (self.strategy in ['google-oauth2','github','apple','windowslive'])",message="social must be set if and only if strategy is google-oauth2, github, apple or windowslive"
+// +kubebuilder:validation:XValidation:rule="!has(self.social) || (self.strategy == 'apple') == (has(self.social.teamId) && has(self.social.keyId))",message="social teamId and keyId must be set if and only if strategy is apple"
+type ConnectionSpec struct {
+	// The Auth0Tenant the resource is managed in. Defaults to the default
+	// tenant of the operator. Cannot be changed once set
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="tenantRef is immutable"
+	TenantRef *TenantReference `json:"tenantRef,omitempty"`
+
+	// The name of the connection.
+	// Auth0 doesn't allow this to be changed once created
+	// +kubebuilder:validation:MaxLength:=128
+	// +kubebuilder:validation:Pattern:=`^[a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?$`
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="name is immutable"
+	Name string `json:"name"`
+
+	// The name of the connection shown on the login page
+	DisplayName string `json:"displayName,omitempty"`
+
+	// The identity provider of the connection.
+	// Auth0 doesn't allow this to be changed once created
+	// +kubebuilder:default:=auth0
+	// +kubebuilder:validation:Enum:={"auth0","oidc","samlp","waad","google-apps","google-oauth2","github","apple","windowslive"}
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="strategy is immutable"
+	Strategy string `json:"strategy,omitempty"`
+
+	// The clients the connection is enabled for
+	EnabledClients []ClientReference `json:"enabledClients,omitempty"`
+
+	// The options of an auth0 (database) connection. Once set, options it
+	// doesn't set are reverted to Auth0's defaults
+	Database *DatabaseConnection `json:"database,omitempty"`
+
+	// The options of an oidc connection
+	OIDC *OIDCConnection `json:"oidc,omitempty"`
+
+	// The options of a samlp connection
+	SAML *SAMLConnection `json:"saml,omitempty"`
+
+	// The options of a waad (Azure AD) connection
+	AzureAD *AzureADConnection `json:"azureAd,omitempty"`
+
+	// The options of a google-apps (Google Workspace) connection
+	GoogleWorkspace *GoogleWorkspaceConnection `json:"googleWorkspace,omitempty"`
+
+	// The options of a google-oauth2, github, apple or windowslive connection
+	Social *SocialConnection `json:"social,omitempty"`
+}
+
+// ConnectionStatus defines the observed state of Connection
+type ConnectionStatus struct {
+	// The Auth0 ID of this connection
+	Auth0Id string `json:"auth0Id,omitempty"`
+
+	// Whether a connection may have been created in Auth0 without its ID
+	// being recorded, in which case it's looked up by its name before
+	// creating another
+	PendingCreate bool `json:"pendingCreate,omitempty"`
+
+	// The latest observations of the Connection's state
+	// +listType=map
+	// +listMapKey=type
+	// +patchStrategy=merge
+	// +patchMergeKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Auth0 ID",type=string,JSONPath=`.status.auth0Id`
+//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// Connection is the Schema for the connections API
+type Connection struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ConnectionSpec   `json:"spec,omitempty"`
+	Status ConnectionStatus `json:"status,omitempty"`
+}
+
+// IsBeingDeleted returns true if the Connection is being deleted (i.e. has a deletion timestamp)
+func (c *Connection) IsBeingDeleted() bool {
+	return c.GetDeletionTimestamp() != nil
+}
+
+// StatusConditions returns the conditions of the Connection for updating
+func (c *Connection) StatusConditions() *[]metav1.Condition {
+	return &c.Status.Conditions
+}
+
+// Auth0Id returns the Auth0 ID of the Connection
+func (c *Connection) Auth0Id() string {
+	return c.Status.Auth0Id
+}
+
+//+kubebuilder:object:root=true
+
+// ConnectionList contains a list of Connection
+type ConnectionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Connection `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Connection{}, &ConnectionList{})
+}
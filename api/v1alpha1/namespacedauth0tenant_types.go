@@ -0,0 +1,70 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NamespacedAuth0TenantSpec defines the desired state of NamespacedAuth0Tenant
+// +kubebuilder:validation:XValidation:rule="has(self.clientSecretRef) != has(self.privateKeyRef)",message="exactly one of clientSecretRef or privateKeyRef must be set"
+type NamespacedAuth0TenantSpec struct {
+	// The domain of the tenant, e.g. example.eu.auth0.com
+	// +kubebuilder:validation:MinLength:=1
+	Domain string `json:"domain"`
+
+	// The client ID of the machine to machine application the operator
+	// uses to manage the tenant
+	// +kubebuilder:validation:MinLength:=1
+	ClientId string `json:"clientId"`
+
+	// The secret in the same namespace holding the client secret of the
+	// machine to machine application
+	ClientSecretRef *SecretRef `json:"clientSecretRef,omitempty"`
+
+	// The secret in the same namespace holding the PEM encoded RSA private
+	// key the machine to machine application authenticates with
+	// (private_key_jwt)
+	PrivateKeyRef *SecretRef `json:"privateKeyRef,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="Domain",type=string,JSONPath=`.spec.domain`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// NamespacedAuth0Tenant is the Schema for the namespacedauth0tenants API. It
+// holds the credentials used to manage an Auth0 tenant, and can only be
+// referenced by resources in its own namespace
+type NamespacedAuth0Tenant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec NamespacedAuth0TenantSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// NamespacedAuth0TenantList contains a list of NamespacedAuth0Tenant
+type NamespacedAuth0TenantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NamespacedAuth0Tenant `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NamespacedAuth0Tenant{}, &NamespacedAuth0TenantList{})
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ConnectionReference refers to an Auth0 connection, either via a
+// Connection in the same namespace or directly by its Auth0 ID
+// +kubebuilder:validation:XValidation:rule="has(self.name) != has(self.auth0Id)",message="exactly one of name or auth0Id must be set"
+type ConnectionReference struct {
+	// The name of a Connection in the same namespace
+	Name string `json:"name,omitempty"`
+
+	// The Auth0 ID of a connection that isn't managed by a Connection
+	Auth0Id string `json:"auth0Id,omitempty"`
+}
+
+type OrganizationBrandingColors struct {
+	// The primary color, e.g. #0059d6
+	// +kubebuilder:validation:Pattern:=`^#[0-9a-fA-F]{6}$`
+	Primary string `json:"primary"`
+
+	// The background color of the login page, e.g. #000000
+	// +kubebuilder:validation:Pattern:=`^#[0-9a-fA-F]{6}$`
+	PageBackground string `json:"pageBackground"`
+}
+
+type OrganizationBranding struct {
+	// The URL of the logo shown on the login page
+	LogoUrl string `json:"logoUrl,omitempty"`
+
+	Colors *OrganizationBrandingColors `json:"colors,omitempty"`
+}
+
+// OrganizationConnection is a connection members of an organization can
+// log in with
+type OrganizationConnection struct {
+	// The connection enabled for the organization
+	ConnectionRef ConnectionReference `json:"connectionRef"`
+
+	// Whether users logging in with the connection are automatically made
+	// members of the organization
+	AssignMembershipOnLogin bool `json:"assignMembershipOnLogin,omitempty"`
+}
+
+// OrganizationSpec defines the desired state of Organization
+type OrganizationSpec struct {
+	// The Auth0Tenant the resource is managed in. Defaults to the default
+	// tenant of the operator. Cannot be changed once set
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="tenantRef is immutable"
+	TenantRef *TenantReference `json:"tenantRef,omitempty"`
+
+	// The name of the organization, used in login URLs
+	// +kubebuilder:validation:MaxLength:=50
+	// +kubebuilder:validation:Pattern:=`^[a-z0-9]([a-z0-9_-]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// The name of the organization shown to users
+	DisplayName string `json:"displayName,omitempty"`
+
+	// The branding of the login pages of the organization
+	Branding *OrganizationBranding `json:"branding,omitempty"`
+
+	// Metadata associated with the organization
+	// +kubebuilder:validation:MaxProperties:=10
+	Metadata map[string]string `json:"metadata,omitempty"`
+
+	// The connections members of the organization can log in with
+	EnabledConnections []OrganizationConnection `json:"enabledConnections,omitempty"`
+}
+
+// OrganizationStatus defines the observed state of Organization
+type OrganizationStatus struct {
+	// The Auth0 ID of this organization
+	Auth0Id string `json:"auth0Id,omitempty"`
+
+	// Whether an organization may have been created in Auth0 without its
+	// ID being recorded, in which case it's looked up by its name before
+	// creating another
+	PendingCreate bool `json:"pendingCreate,omitempty"`
+
+	// The latest observations of the Organization's state
+	// +listType=map
+	// +listMapKey=type
+	// +patchStrategy=merge
+	// +patchMergeKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Auth0 ID",type=string,JSONPath=`.status.auth0Id`
+//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// Organization is the Schema for the organizations API
+type Organization struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OrganizationSpec   `json:"spec,omitempty"`
+	Status OrganizationStatus `json:"status,omitempty"`
+}
+
+// IsBeingDeleted returns true if the Organization is being deleted (i.e. has a deletion timestamp)
+func (o *Organization) IsBeingDeleted() bool {
+	return o.GetDeletionTimestamp() != nil
+}
+
+// StatusConditions returns the conditions of the Organization for updating
+func (o *Organization) StatusConditions() *[]metav1.Condition {
+	return &o.Status.Conditions
+}
+
+// Auth0Id returns the Auth0 ID of the Organization
+func (o *Organization) Auth0Id() string {
+	return o.Status.Auth0Id
+}
+
+//+kubebuilder:object:root=true
+
+// OrganizationList contains a list of Organization
+type OrganizationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Organization `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Organization{}, &OrganizationList{})
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type ResourceServerScope struct {
+	// The scope value, e.g. read:messages
+	Value string `json:"value"`
+
+	// The description of the scope
+	Description string `json:"description,omitempty"`
+}
+
+// ResourceServerSpec defines the desired state of ResourceServer
+type ResourceServerSpec struct {
+	// The Auth0Tenant the resource is managed in. Defaults to the default
+	// tenant of the operator. Cannot be changed once set
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="tenantRef is immutable"
+	TenantRef *TenantReference `json:"tenantRef,omitempty"`
+
+	// The name of the resource server
+	Name string `json:"name,omitempty"`
+
+	// The unique identifier (audience) of the resource server.
+	// Auth0 doesn't allow this to be changed once created
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="identifier is immutable"
+	Identifier string `json:"identifier"`
+
+	// The scopes supported by the resource server
+	Scopes []ResourceServerScope `json:"scopes,omitempty"`
+
+	// The algorithm used to sign access tokens
+	// +kubebuilder:validation:Enum:={"HS256","RS256","PS256"}
+	SigningAlgorithm string `json:"signingAlg,omitempty"`
+
+	// Whether refresh tokens can be issued for the resource server
+	AllowOfflineAccess *bool `json:"allowOfflineAccess,omitempty"`
+
+	// The lifetime of access tokens in seconds
+	// +kubebuilder:validation:Minimum:=0
+	TokenLifetime *int `json:"tokenLifetime,omitempty"`
+
+	// The lifetime of access tokens issued from browser based flows in seconds.
+	// Cannot be larger than tokenLifetime
+	// +kubebuilder:validation:Minimum:=0
+	TokenLifetimeForWeb *int `json:"tokenLifetimeForWeb,omitempty"`
+
+	// Whether RBAC authorization policies are enforced for the resource server
+	EnforcePolicies *bool `json:"enforcePolicies,omitempty"`
+
+	// The dialect of access tokens. access_token_authz adds the permissions
+	// claim when enforcePolicies is enabled
+	// +kubebuilder:validation:Enum:={"access_token","access_token_authz"}
+	TokenDialect string `json:"tokenDialect,omitempty"`
+
+	// Whether consent can be skipped for verifiable first party clients
+	SkipConsentForVerifiableFirstPartyClients *bool `json:"skipConsentForVerifiableFirstPartyClients,omitempty"`
+}
+
+// ResourceServerStatus defines the observed state of ResourceServer
+type ResourceServerStatus struct {
+	// The Auth0 ID of this resource server
+	Auth0Id string `json:"auth0Id,omitempty"`
+
+	// Whether a resource server may have been created in Auth0 without its
+	// ID being recorded, in which case it's looked up by its identifier
+	// before creating another
+	PendingCreate bool `json:"pendingCreate,omitempty"`
+
+	// The latest observations of the ResourceServer's state
+	// +listType=map
+	// +listMapKey=type
+	// +patchStrategy=merge
+	// +patchMergeKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Auth0 ID",type=string,JSONPath=`.status.auth0Id`
+//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// ResourceServer is the Schema for the resourceservers API
+type ResourceServer struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ResourceServerSpec   `json:"spec,omitempty"`
+	Status ResourceServerStatus `json:"status,omitempty"`
+}
+
+// IsBeingDeleted returns true if the ResourceServer is being deleted (i.e. has a deletion timestamp)
+func (rs *ResourceServer) IsBeingDeleted() bool {
+	return rs.GetDeletionTimestamp() != nil
+}
+
+// StatusConditions returns the conditions of the ResourceServer for updating
+func (rs *ResourceServer) StatusConditions() *[]metav1.Condition {
+	return &rs.Status.Conditions
+}
+
+// Auth0Id returns the Auth0 ID of the ResourceServer
+func (rs *ResourceServer) Auth0Id() string {
+	return rs.Status.Auth0Id
+}
+
+//+kubebuilder:object:root=true
+
+// ResourceServerList contains a list of ResourceServer
+type ResourceServerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ResourceServer `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ResourceServer{}, &ResourceServerList{})
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RolePermission is a scope of an API granted by a Role
+type RolePermission struct {
+	// The identifier (audience) of the API the scope belongs to
+	// +kubebuilder:validation:MinLength:=1
+	ResourceServerIdentifier string `json:"resourceServerIdentifier"`
+
+	// The scope of the API, e.g. read:messages
+	// +kubebuilder:validation:MinLength:=1
+	Scope string `json:"scope"`
+}
+
+// RoleSpec defines the desired state of Role
+type RoleSpec struct {
+	// The Auth0Tenant the resource is managed in. Defaults to the default
+	// tenant of the operator. Cannot be changed once set
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="tenantRef is immutable"
+	TenantRef *TenantReference `json:"tenantRef,omitempty"`
+
+	// The name of the role
+	// +kubebuilder:validation:MinLength:=1
+	Name string `json:"name"`
+
+	// The description of the role
+	Description string `json:"description,omitempty"`
+
+	// The permissions granted by the role
+	// +listType=map
+	// +listMapKey=resourceServerIdentifier
+	// +listMapKey=scope
+	Permissions []RolePermission `json:"permissions,omitempty"`
+}
+
+// RoleStatus defines the observed state of Role
+type RoleStatus struct {
+	// The Auth0 ID of this role
+	Auth0Id string `json:"auth0Id,omitempty"`
+
+	// Whether a role may have been created in Auth0 without its ID being
+	// recorded, in which case it's looked up by its name before creating
+	// another
+	PendingCreate bool `json:"pendingCreate,omitempty"`
+
+	// The latest observations of the Role's state
+	// +listType=map
+	// +listMapKey=type
+	// +patchStrategy=merge
+	// +patchMergeKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Auth0 ID",type=string,JSONPath=`.status.auth0Id`
+//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// Role is the Schema for the roles API
+type Role struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RoleSpec   `json:"spec,omitempty"`
+	Status RoleStatus `json:"status,omitempty"`
+}
+
+// IsBeingDeleted returns true if the Role is being deleted (i.e. has a deletion timestamp)
+func (r *Role) IsBeingDeleted() bool {
+	return r.GetDeletionTimestamp() != nil
+}
+
+// StatusConditions returns the conditions of the Role for updating
+func (r *Role) StatusConditions() *[]metav1.Condition {
+	return &r.Status.Conditions
+}
+
+// Auth0Id returns the Auth0 ID of the Role
+func (r *Role) Auth0Id() string {
+	return r.Status.Auth0Id
+}
+
+//+kubebuilder:object:root=true
+
+// RoleList contains a list of Role
+type RoleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Role `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Role{}, &RoleList{})
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ActionReference refers to an Auth0 action, either via an Action in the
+// same namespace or directly by its Auth0 ID
+// +kubebuilder:validation:XValidation:rule="has(self.name) != has(self.auth0Id)",message="exactly one of name or auth0Id must be set"
+type ActionReference struct {
+	// The name of an Action in the same namespace
+	Name string `json:"name,omitempty"`
+
+	// The Auth0 ID of an action that isn't managed by an Action
+	Auth0Id string `json:"auth0Id,omitempty"`
+}
+
+// TriggerBindingAction is an action bound to a trigger
+type TriggerBindingAction struct {
+	// The action to bind
+	ActionRef ActionReference `json:"actionRef"`
+
+	// The name of the binding shown in the flow editor. Defaults to the
+	// name of the action
+	DisplayName string `json:"displayName,omitempty"`
+}
+
+// TriggerBindingSpec defines the desired state of TriggerBinding
+type TriggerBindingSpec struct {
+	// The Auth0Tenant the resource is managed in. Defaults to the default
+	// tenant of the operator. Cannot be changed once set
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="tenantRef is immutable"
+	TenantRef *TenantReference `json:"tenantRef,omitempty"`
+
+	// The ID of the trigger. Auth0 has a single set of bindings per trigger,
+	// so only the oldest TriggerBinding for a trigger of a tenant manages
+	// them. Others are reported as duplicates and ignored
+	// +kubebuilder:validation:Enum:={"post-login","credentials-exchange","pre-user-registration","post-user-registration","post-change-password","send-phone-message","password-reset-post-challenge"}
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="trigger is immutable"
+	Trigger string `json:"trigger"`
+
+	// The actions bound to the trigger, in the order they're executed
+	Actions []TriggerBindingAction `json:"actions,omitempty"`
+}
+
+// TriggerBindingStatus defines the observed state of TriggerBinding
+type TriggerBindingStatus struct {
+	// The Auth0 IDs of the actions bound to the trigger, in order
+	BoundActions []string `json:"boundActions,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// TriggerBinding is the Schema for the triggerbindings API
+type TriggerBinding struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TriggerBindingSpec   `json:"spec,omitempty"`
+	Status TriggerBindingStatus `json:"status,omitempty"`
+}
+
+// IsBeingDeleted returns true if the TriggerBinding is being deleted (i.e. has a deletion timestamp)
+func (b *TriggerBinding) IsBeingDeleted() bool {
+	return b.GetDeletionTimestamp() != nil
+}
+
+//+kubebuilder:object:root=true
+
+// TriggerBindingList contains a list of TriggerBinding
+type TriggerBindingList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TriggerBinding `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TriggerBinding{}, &TriggerBindingList{})
+}
@@ -21,30 +21,1442 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Action) DeepCopyInto(out *Action) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Action.
+func (in *Action) DeepCopy() *Action {
+	if in == nil {
+		return nil
+	}
+	out := new(Action)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Action) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActionDependency) DeepCopyInto(out *ActionDependency) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActionDependency.
+func (in *ActionDependency) DeepCopy() *ActionDependency {
+	if in == nil {
+		return nil
+	}
+	out := new(ActionDependency)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActionList) DeepCopyInto(out *ActionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Action, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActionList.
+func (in *ActionList) DeepCopy() *ActionList {
+	if in == nil {
+		return nil
+	}
+	out := new(ActionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ActionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActionReference) DeepCopyInto(out *ActionReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActionReference.
+func (in *ActionReference) DeepCopy() *ActionReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ActionReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActionSecret) DeepCopyInto(out *ActionSecret) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActionSecret.
+func (in *ActionSecret) DeepCopy() *ActionSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(ActionSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActionSpec) DeepCopyInto(out *ActionSpec) {
+	*out = *in
+	if in.TenantRef != nil {
+		in, out := &in.TenantRef, &out.TenantRef
+		*out = new(TenantReference)
+		**out = **in
+	}
+	out.Trigger = in.Trigger
+	out.CodeRef = in.CodeRef
+	if in.Dependencies != nil {
+		in, out := &in.Dependencies, &out.Dependencies
+		*out = make([]ActionDependency, len(*in))
+		copy(*out, *in)
+	}
+	if in.Secrets != nil {
+		in, out := &in.Secrets, &out.Secrets
+		*out = make([]ActionSecret, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActionSpec.
+func (in *ActionSpec) DeepCopy() *ActionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ActionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActionStatus) DeepCopyInto(out *ActionStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActionStatus.
+func (in *ActionStatus) DeepCopy() *ActionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ActionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActionTrigger) DeepCopyInto(out *ActionTrigger) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActionTrigger.
+func (in *ActionTrigger) DeepCopy() *ActionTrigger {
+	if in == nil {
+		return nil
+	}
+	out := new(ActionTrigger)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Auth0Tenant) DeepCopyInto(out *Auth0Tenant) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Auth0Tenant.
+func (in *Auth0Tenant) DeepCopy() *Auth0Tenant {
+	if in == nil {
+		return nil
+	}
+	out := new(Auth0Tenant)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Auth0Tenant) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Auth0TenantList) DeepCopyInto(out *Auth0TenantList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Auth0Tenant, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Auth0TenantList.
+func (in *Auth0TenantList) DeepCopy() *Auth0TenantList {
+	if in == nil {
+		return nil
+	}
+	out := new(Auth0TenantList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Auth0TenantList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Auth0TenantSpec) DeepCopyInto(out *Auth0TenantSpec) {
+	*out = *in
+	if in.ClientSecretRef != nil {
+		in, out := &in.ClientSecretRef, &out.ClientSecretRef
+		*out = new(NamespacedSecretRef)
+		**out = **in
+	}
+	if in.PrivateKeyRef != nil {
+		in, out := &in.PrivateKeyRef, &out.PrivateKeyRef
+		*out = new(NamespacedSecretRef)
+		**out = **in
+	}
+	if in.AllowedNamespaces != nil {
+		in, out := &in.AllowedNamespaces, &out.AllowedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Auth0TenantSpec.
+func (in *Auth0TenantSpec) DeepCopy() *Auth0TenantSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(Auth0TenantSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureADConnection) DeepCopyInto(out *AzureADConnection) {
+	*out = *in
+	out.ClientSecretRef = in.ClientSecretRef
+	if in.UseCommonEndpoint != nil {
+		in, out := &in.UseCommonEndpoint, &out.UseCommonEndpoint
+		*out = new(bool)
+		**out = **in
+	}
+	if in.BasicProfile != nil {
+		in, out := &in.BasicProfile, &out.BasicProfile
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ExtendedProfile != nil {
+		in, out := &in.ExtendedProfile, &out.ExtendedProfile
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EnableUsersApi != nil {
+		in, out := &in.EnableUsersApi, &out.EnableUsersApi
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DomainAliases != nil {
+		in, out := &in.DomainAliases, &out.DomainAliases
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureADConnection.
+func (in *AzureADConnection) DeepCopy() *AzureADConnection {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureADConnection)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Client) DeepCopyInto(out *Client) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Client.
+func (in *Client) DeepCopy() *Client {
+	if in == nil {
+		return nil
+	}
+	out := new(Client)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Client) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientGrant) DeepCopyInto(out *ClientGrant) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientGrant.
+func (in *ClientGrant) DeepCopy() *ClientGrant {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientGrant)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClientGrant) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientGrantList) DeepCopyInto(out *ClientGrantList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClientGrant, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientGrantList.
+func (in *ClientGrantList) DeepCopy() *ClientGrantList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientGrantList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClientGrantList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientGrantSpec) DeepCopyInto(out *ClientGrantSpec) {
+	*out = *in
+	if in.TenantRef != nil {
+		in, out := &in.TenantRef, &out.TenantRef
+		*out = new(TenantReference)
+		**out = **in
+	}
+	out.ClientRef = in.ClientRef
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientGrantSpec.
+func (in *ClientGrantSpec) DeepCopy() *ClientGrantSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientGrantSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientGrantStatus) DeepCopyInto(out *ClientGrantStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientGrantStatus.
+func (in *ClientGrantStatus) DeepCopy() *ClientGrantStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientGrantStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientList) DeepCopyInto(out *ClientList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Client, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientList.
+func (in *ClientList) DeepCopy() *ClientList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClientList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientReference) DeepCopyInto(out *ClientReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientReference.
+func (in *ClientReference) DeepCopy() *ClientReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientSecret) DeepCopyInto(out *ClientSecret) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+	out.OutputSecretRef = in.OutputSecretRef
+	if in.GeneratedSecretRef != nil {
+		in, out := &in.GeneratedSecretRef, &out.GeneratedSecretRef
+		*out = new(SecretRef)
+		**out = **in
+	}
+	if in.OutputKeys != nil {
+		in, out := &in.OutputKeys, &out.OutputKeys
+		*out = new(OutputSecretKeys)
+		**out = **in
+	}
+	if in.OutputTemplates != nil {
+		in, out := &in.OutputTemplates, &out.OutputTemplates
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.RotationSchedule != nil {
+		in, out := &in.RotationSchedule, &out.RotationSchedule
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.RotationOverlap != nil {
+		in, out := &in.RotationOverlap, &out.RotationOverlap
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientSecret.
+func (in *ClientSecret) DeepCopy() *ClientSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientSpec) DeepCopyInto(out *ClientSpec) {
+	*out = *in
+	if in.TenantRef != nil {
+		in, out := &in.TenantRef, &out.TenantRef
+		*out = new(TenantReference)
+		**out = **in
+	}
+	if in.CallbackUrls != nil {
+		in, out := &in.CallbackUrls, &out.CallbackUrls
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.ClientSecret.DeepCopyInto(&out.ClientSecret)
+	if in.OutputConfigMap != nil {
+		in, out := &in.OutputConfigMap, &out.OutputConfigMap
+		*out = new(OutputConfigMap)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SyncInterval != nil {
+		in, out := &in.SyncInterval, &out.SyncInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientSpec.
+func (in *ClientSpec) DeepCopy() *ClientSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientStatus) DeepCopyInto(out *ClientStatus) {
+	*out = *in
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.DriftedFields != nil {
+		in, out := &in.DriftedFields, &out.DriftedFields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastRotated != nil {
+		in, out := &in.LastRotated, &out.LastRotated
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientStatus.
+func (in *ClientStatus) DeepCopy() *ClientStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMapRef) DeepCopyInto(out *ConfigMapRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapRef.
+func (in *ConfigMapRef) DeepCopy() *ConfigMapRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMapRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Connection) DeepCopyInto(out *Connection) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Connection.
+func (in *Connection) DeepCopy() *Connection {
+	if in == nil {
+		return nil
+	}
+	out := new(Connection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Connection) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectionList) DeepCopyInto(out *ConnectionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Connection, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectionList.
+func (in *ConnectionList) DeepCopy() *ConnectionList {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ConnectionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectionReference) DeepCopyInto(out *ConnectionReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectionReference.
+func (in *ConnectionReference) DeepCopy() *ConnectionReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectionReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectionSpec) DeepCopyInto(out *ConnectionSpec) {
+	*out = *in
+	if in.TenantRef != nil {
+		in, out := &in.TenantRef, &out.TenantRef
+		*out = new(TenantReference)
+		**out = **in
+	}
+	if in.EnabledClients != nil {
+		in, out := &in.EnabledClients, &out.EnabledClients
+		*out = make([]ClientReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Database != nil {
+		in, out := &in.Database, &out.Database
+		*out = new(DatabaseConnection)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OIDC != nil {
+		in, out := &in.OIDC, &out.OIDC
+		*out = new(OIDCConnection)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SAML != nil {
+		in, out := &in.SAML, &out.SAML
+		*out = new(SAMLConnection)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AzureAD != nil {
+		in, out := &in.AzureAD, &out.AzureAD
+		*out = new(AzureADConnection)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GoogleWorkspace != nil {
+		in, out := &in.GoogleWorkspace, &out.GoogleWorkspace
+		*out = new(GoogleWorkspaceConnection)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Social != nil {
+		in, out := &in.Social, &out.Social
+		*out = new(SocialConnection)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectionSpec.
+func (in *ConnectionSpec) DeepCopy() *ConnectionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectionStatus) DeepCopyInto(out *ConnectionStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectionStatus.
+func (in *ConnectionStatus) DeepCopy() *ConnectionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseConnection) DeepCopyInto(out *DatabaseConnection) {
+	*out = *in
+	if in.PasswordComplexityOptions != nil {
+		in, out := &in.PasswordComplexityOptions, &out.PasswordComplexityOptions
+		*out = new(PasswordComplexityOptions)
+		**out = **in
+	}
+	if in.PasswordHistory != nil {
+		in, out := &in.PasswordHistory, &out.PasswordHistory
+		*out = new(PasswordHistory)
+		**out = **in
+	}
+	if in.PasswordDictionary != nil {
+		in, out := &in.PasswordDictionary, &out.PasswordDictionary
+		*out = new(PasswordDictionary)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PasswordNoPersonalInfo != nil {
+		in, out := &in.PasswordNoPersonalInfo, &out.PasswordNoPersonalInfo
+		*out = new(PasswordNoPersonalInfo)
+		**out = **in
+	}
+	if in.RequiresUsername != nil {
+		in, out := &in.RequiresUsername, &out.RequiresUsername
+		*out = new(bool)
+		**out = **in
+	}
+	if in.UsernameLength != nil {
+		in, out := &in.UsernameLength, &out.UsernameLength
+		*out = new(UsernameLength)
+		**out = **in
+	}
+	if in.DisableSignup != nil {
+		in, out := &in.DisableSignup, &out.DisableSignup
+		*out = new(bool)
+		**out = **in
+	}
+	if in.BruteForceProtection != nil {
+		in, out := &in.BruteForceProtection, &out.BruteForceProtection
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseConnection.
+func (in *DatabaseConnection) DeepCopy() *DatabaseConnection {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseConnection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GoogleWorkspaceConnection) DeepCopyInto(out *GoogleWorkspaceConnection) {
+	*out = *in
+	out.ClientSecretRef = in.ClientSecretRef
+	if in.BasicProfile != nil {
+		in, out := &in.BasicProfile, &out.BasicProfile
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ExtendedProfile != nil {
+		in, out := &in.ExtendedProfile, &out.ExtendedProfile
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EnableUsersApi != nil {
+		in, out := &in.EnableUsersApi, &out.EnableUsersApi
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DomainAliases != nil {
+		in, out := &in.DomainAliases, &out.DomainAliases
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GoogleWorkspaceConnection.
+func (in *GoogleWorkspaceConnection) DeepCopy() *GoogleWorkspaceConnection {
+	if in == nil {
+		return nil
+	}
+	out := new(GoogleWorkspaceConnection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespacedAuth0Tenant) DeepCopyInto(out *NamespacedAuth0Tenant) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespacedAuth0Tenant.
+func (in *NamespacedAuth0Tenant) DeepCopy() *NamespacedAuth0Tenant {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespacedAuth0Tenant)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NamespacedAuth0Tenant) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespacedAuth0TenantList) DeepCopyInto(out *NamespacedAuth0TenantList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NamespacedAuth0Tenant, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespacedAuth0TenantList.
+func (in *NamespacedAuth0TenantList) DeepCopy() *NamespacedAuth0TenantList {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespacedAuth0TenantList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NamespacedAuth0TenantList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespacedAuth0TenantSpec) DeepCopyInto(out *NamespacedAuth0TenantSpec) {
+	*out = *in
+	if in.ClientSecretRef != nil {
+		in, out := &in.ClientSecretRef, &out.ClientSecretRef
+		*out = new(SecretRef)
+		**out = **in
+	}
+	if in.PrivateKeyRef != nil {
+		in, out := &in.PrivateKeyRef, &out.PrivateKeyRef
+		*out = new(SecretRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespacedAuth0TenantSpec.
+func (in *NamespacedAuth0TenantSpec) DeepCopy() *NamespacedAuth0TenantSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespacedAuth0TenantSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespacedSecretRef) DeepCopyInto(out *NamespacedSecretRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespacedSecretRef.
+func (in *NamespacedSecretRef) DeepCopy() *NamespacedSecretRef {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespacedSecretRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OIDCConnection) DeepCopyInto(out *OIDCConnection) {
+	*out = *in
+	if in.ClientSecretRef != nil {
+		in, out := &in.ClientSecretRef, &out.ClientSecretRef
+		*out = new(SecretRef)
+		**out = **in
+	}
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DomainAliases != nil {
+		in, out := &in.DomainAliases, &out.DomainAliases
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCConnection.
+func (in *OIDCConnection) DeepCopy() *OIDCConnection {
+	if in == nil {
+		return nil
+	}
+	out := new(OIDCConnection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Organization) DeepCopyInto(out *Organization) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Organization.
+func (in *Organization) DeepCopy() *Organization {
+	if in == nil {
+		return nil
+	}
+	out := new(Organization)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Organization) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationBranding) DeepCopyInto(out *OrganizationBranding) {
+	*out = *in
+	if in.Colors != nil {
+		in, out := &in.Colors, &out.Colors
+		*out = new(OrganizationBrandingColors)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationBranding.
+func (in *OrganizationBranding) DeepCopy() *OrganizationBranding {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationBranding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationBrandingColors) DeepCopyInto(out *OrganizationBrandingColors) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationBrandingColors.
+func (in *OrganizationBrandingColors) DeepCopy() *OrganizationBrandingColors {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationBrandingColors)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationConnection) DeepCopyInto(out *OrganizationConnection) {
+	*out = *in
+	out.ConnectionRef = in.ConnectionRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationConnection.
+func (in *OrganizationConnection) DeepCopy() *OrganizationConnection {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationConnection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationList) DeepCopyInto(out *OrganizationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Organization, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationList.
+func (in *OrganizationList) DeepCopy() *OrganizationList {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OrganizationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationSpec) DeepCopyInto(out *OrganizationSpec) {
+	*out = *in
+	if in.TenantRef != nil {
+		in, out := &in.TenantRef, &out.TenantRef
+		*out = new(TenantReference)
+		**out = **in
+	}
+	if in.Branding != nil {
+		in, out := &in.Branding, &out.Branding
+		*out = new(OrganizationBranding)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.EnabledConnections != nil {
+		in, out := &in.EnabledConnections, &out.EnabledConnections
+		*out = make([]OrganizationConnection, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationSpec.
+func (in *OrganizationSpec) DeepCopy() *OrganizationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationStatus) DeepCopyInto(out *OrganizationStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationStatus.
+func (in *OrganizationStatus) DeepCopy() *OrganizationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OutputConfigMap) DeepCopyInto(out *OutputConfigMap) {
+	*out = *in
+	if in.Templates != nil {
+		in, out := &in.Templates, &out.Templates
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutputConfigMap.
+func (in *OutputConfigMap) DeepCopy() *OutputConfigMap {
+	if in == nil {
+		return nil
+	}
+	out := new(OutputConfigMap)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OutputSecretKeys) DeepCopyInto(out *OutputSecretKeys) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutputSecretKeys.
+func (in *OutputSecretKeys) DeepCopy() *OutputSecretKeys {
+	if in == nil {
+		return nil
+	}
+	out := new(OutputSecretKeys)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PasswordComplexityOptions) DeepCopyInto(out *PasswordComplexityOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordComplexityOptions.
+func (in *PasswordComplexityOptions) DeepCopy() *PasswordComplexityOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(PasswordComplexityOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PasswordDictionary) DeepCopyInto(out *PasswordDictionary) {
+	*out = *in
+	if in.Dictionary != nil {
+		in, out := &in.Dictionary, &out.Dictionary
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordDictionary.
+func (in *PasswordDictionary) DeepCopy() *PasswordDictionary {
+	if in == nil {
+		return nil
+	}
+	out := new(PasswordDictionary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PasswordHistory) DeepCopyInto(out *PasswordHistory) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordHistory.
+func (in *PasswordHistory) DeepCopy() *PasswordHistory {
+	if in == nil {
+		return nil
+	}
+	out := new(PasswordHistory)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PasswordNoPersonalInfo) DeepCopyInto(out *PasswordNoPersonalInfo) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordNoPersonalInfo.
+func (in *PasswordNoPersonalInfo) DeepCopy() *PasswordNoPersonalInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(PasswordNoPersonalInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceServer) DeepCopyInto(out *ResourceServer) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceServer.
+func (in *ResourceServer) DeepCopy() *ResourceServer {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceServer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ResourceServer) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceServerList) DeepCopyInto(out *ResourceServerList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ResourceServer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceServerList.
+func (in *ResourceServerList) DeepCopy() *ResourceServerList {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceServerList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ResourceServerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceServerScope) DeepCopyInto(out *ResourceServerScope) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceServerScope.
+func (in *ResourceServerScope) DeepCopy() *ResourceServerScope {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceServerScope)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceServerSpec) DeepCopyInto(out *ResourceServerSpec) {
+	*out = *in
+	if in.TenantRef != nil {
+		in, out := &in.TenantRef, &out.TenantRef
+		*out = new(TenantReference)
+		**out = **in
+	}
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]ResourceServerScope, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowOfflineAccess != nil {
+		in, out := &in.AllowOfflineAccess, &out.AllowOfflineAccess
+		*out = new(bool)
+		**out = **in
+	}
+	if in.TokenLifetime != nil {
+		in, out := &in.TokenLifetime, &out.TokenLifetime
+		*out = new(int)
+		**out = **in
+	}
+	if in.TokenLifetimeForWeb != nil {
+		in, out := &in.TokenLifetimeForWeb, &out.TokenLifetimeForWeb
+		*out = new(int)
+		**out = **in
+	}
+	if in.EnforcePolicies != nil {
+		in, out := &in.EnforcePolicies, &out.EnforcePolicies
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SkipConsentForVerifiableFirstPartyClients != nil {
+		in, out := &in.SkipConsentForVerifiableFirstPartyClients, &out.SkipConsentForVerifiableFirstPartyClients
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceServerSpec.
+func (in *ResourceServerSpec) DeepCopy() *ResourceServerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceServerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceServerStatus) DeepCopyInto(out *ResourceServerStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceServerStatus.
+func (in *ResourceServerStatus) DeepCopy() *ResourceServerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceServerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Role) DeepCopyInto(out *Role) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Client.
-func (in *Client) DeepCopy() *Client {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Role.
+func (in *Role) DeepCopy() *Role {
 	if in == nil {
 		return nil
 	}
-	out := new(Client)
+	out := new(Role)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Client) DeepCopyObject() runtime.Object {
+func (in *Role) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -52,31 +1464,31 @@ func (in *Client) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClientList) DeepCopyInto(out *ClientList) {
+func (in *RoleList) DeepCopyInto(out *RoleList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Client, len(*in))
+		*out = make([]Role, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientList.
-func (in *ClientList) DeepCopy() *ClientList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleList.
+func (in *RoleList) DeepCopy() *RoleList {
 	if in == nil {
 		return nil
 	}
-	out := new(ClientList)
+	out := new(RoleList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClientList) DeepCopyObject() runtime.Object {
+func (in *RoleList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -84,61 +1496,101 @@ func (in *ClientList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClientSecret) DeepCopyInto(out *ClientSecret) {
+func (in *RolePermission) DeepCopyInto(out *RolePermission) {
 	*out = *in
-	out.SecretRef = in.SecretRef
-	out.OutputSecretRef = in.OutputSecretRef
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientSecret.
-func (in *ClientSecret) DeepCopy() *ClientSecret {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolePermission.
+func (in *RolePermission) DeepCopy() *RolePermission {
 	if in == nil {
 		return nil
 	}
-	out := new(ClientSecret)
+	out := new(RolePermission)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClientSpec) DeepCopyInto(out *ClientSpec) {
+func (in *RoleSpec) DeepCopyInto(out *RoleSpec) {
 	*out = *in
-	if in.CallbackUrls != nil {
-		in, out := &in.CallbackUrls, &out.CallbackUrls
-		*out = make([]string, len(*in))
+	if in.TenantRef != nil {
+		in, out := &in.TenantRef, &out.TenantRef
+		*out = new(TenantReference)
+		**out = **in
+	}
+	if in.Permissions != nil {
+		in, out := &in.Permissions, &out.Permissions
+		*out = make([]RolePermission, len(*in))
 		copy(*out, *in)
 	}
-	if in.Metadata != nil {
-		in, out := &in.Metadata, &out.Metadata
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleSpec.
+func (in *RoleSpec) DeepCopy() *RoleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleStatus) DeepCopyInto(out *RoleStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	out.ClientSecret = in.ClientSecret
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientSpec.
-func (in *ClientSpec) DeepCopy() *ClientSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleStatus.
+func (in *RoleStatus) DeepCopy() *RoleStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ClientSpec)
+	out := new(RoleStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClientStatus) DeepCopyInto(out *ClientStatus) {
+func (in *SAMLConnection) DeepCopyInto(out *SAMLConnection) {
 	*out = *in
+	if in.DisableSignOut != nil {
+		in, out := &in.DisableSignOut, &out.DisableSignOut
+		*out = new(bool)
+		**out = **in
+	}
+	out.SigningCertRef = in.SigningCertRef
+	if in.SignSAMLRequest != nil {
+		in, out := &in.SignSAMLRequest, &out.SignSAMLRequest
+		*out = new(bool)
+		**out = **in
+	}
+	if in.FieldsMap != nil {
+		in, out := &in.FieldsMap, &out.FieldsMap
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.DomainAliases != nil {
+		in, out := &in.DomainAliases, &out.DomainAliases
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientStatus.
-func (in *ClientStatus) DeepCopy() *ClientStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SAMLConnection.
+func (in *SAMLConnection) DeepCopy() *SAMLConnection {
 	if in == nil {
 		return nil
 	}
-	out := new(ClientStatus)
+	out := new(SAMLConnection)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -157,3 +1609,179 @@ func (in *SecretRef) DeepCopy() *SecretRef {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SocialConnection) DeepCopyInto(out *SocialConnection) {
+	*out = *in
+	out.ClientSecretRef = in.ClientSecretRef
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NonPersistentAttributes != nil {
+		in, out := &in.NonPersistentAttributes, &out.NonPersistentAttributes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SocialConnection.
+func (in *SocialConnection) DeepCopy() *SocialConnection {
+	if in == nil {
+		return nil
+	}
+	out := new(SocialConnection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantReference) DeepCopyInto(out *TenantReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantReference.
+func (in *TenantReference) DeepCopy() *TenantReference {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerBinding) DeepCopyInto(out *TriggerBinding) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerBinding.
+func (in *TriggerBinding) DeepCopy() *TriggerBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerBinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TriggerBinding) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerBindingAction) DeepCopyInto(out *TriggerBindingAction) {
+	*out = *in
+	out.ActionRef = in.ActionRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerBindingAction.
+func (in *TriggerBindingAction) DeepCopy() *TriggerBindingAction {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerBindingAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerBindingList) DeepCopyInto(out *TriggerBindingList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TriggerBinding, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerBindingList.
+func (in *TriggerBindingList) DeepCopy() *TriggerBindingList {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerBindingList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TriggerBindingList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerBindingSpec) DeepCopyInto(out *TriggerBindingSpec) {
+	*out = *in
+	if in.TenantRef != nil {
+		in, out := &in.TenantRef, &out.TenantRef
+		*out = new(TenantReference)
+		**out = **in
+	}
+	if in.Actions != nil {
+		in, out := &in.Actions, &out.Actions
+		*out = make([]TriggerBindingAction, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerBindingSpec.
+func (in *TriggerBindingSpec) DeepCopy() *TriggerBindingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerBindingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerBindingStatus) DeepCopyInto(out *TriggerBindingStatus) {
+	*out = *in
+	if in.BoundActions != nil {
+		in, out := &in.BoundActions, &out.BoundActions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerBindingStatus.
+func (in *TriggerBindingStatus) DeepCopy() *TriggerBindingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerBindingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UsernameLength) DeepCopyInto(out *UsernameLength) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UsernameLength.
+func (in *UsernameLength) DeepCopy() *UsernameLength {
+	if in == nil {
+		return nil
+	}
+	out := new(UsernameLength)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -17,10 +17,10 @@ limitations under the License.
 package main
 
 import (
-	"context"
 	"flag"
 	"fmt"
 	"os"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -34,9 +34,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 
-	"github.com/auth0/go-auth0/management"
 	auth0v1alpha1 "github.com/rgracey/auth0-operator/api/v1alpha1"
 	"github.com/rgracey/auth0-operator/internal/controller"
+	"github.com/rgracey/auth0-operator/internal/ratelimit"
 	//+kubebuilder:scaffold:imports
 )
 
@@ -52,7 +52,6 @@ func init() {
 	//+kubebuilder:scaffold:scheme
 }
 
-// mustGetEnv returns the value of an environment variable or panics if it is not set.
 func mustGetEnv(key string) string {
 	value, ok := os.LookupEnv(key)
 	if !ok {
@@ -65,11 +64,30 @@ func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
 	var probeAddr string
+	var defaultDeletionPolicy string
+	var syncPeriod time.Duration
+	var defaultTenant string
+	var auth0RateLimit float64
+	var auth0RateLimitBurst int
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.StringVar(&defaultDeletionPolicy, "default-deletion-policy", auth0v1alpha1.DeletionPolicyDelete,
+		"What happens to Auth0 clients when Clients that don't specify a deletion policy are deleted. "+
+			"One of Delete or Orphan.")
+	flag.DurationVar(&syncPeriod, "sync-period", 10*time.Minute,
+		"How often Clients that don't specify a sync interval are synced with Auth0. 0 disables periodic syncs.")
+	flag.StringVar(&defaultTenant, "default-tenant", "",
+		"The Auth0Tenant that resources without a tenantRef are managed in. "+
+			"If not set, they're managed in the tenant configured by the AUTH0_DOMAIN, AUTH0_CLIENT_ID and "+
+			"AUTH0_CLIENT_SECRET environment variables, if set.")
+	flag.Float64Var(&auth0RateLimit, "auth0-rate-limit", 5,
+		"The maximum number of requests per second made to the Auth0 management API. 0 disables the limit.")
+	flag.IntVar(&auth0RateLimitBurst, "auth0-rate-limit-burst", 10,
+		"The number of requests that can be made to the Auth0 management API at once before being rate limited. "+
+			"Must be at least 1 when the rate limit is enabled.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -78,6 +96,28 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	if defaultDeletionPolicy != auth0v1alpha1.DeletionPolicyDelete &&
+		defaultDeletionPolicy != auth0v1alpha1.DeletionPolicyOrphan {
+		setupLog.Error(nil, "invalid default deletion policy", "policy", defaultDeletionPolicy)
+		os.Exit(1)
+	}
+
+	if syncPeriod < 0 {
+		setupLog.Error(nil, "invalid sync period", "period", syncPeriod)
+		os.Exit(1)
+	}
+
+	if auth0RateLimit < 0 {
+		setupLog.Error(nil, "invalid Auth0 rate limit", "limit", auth0RateLimit)
+		os.Exit(1)
+	}
+
+	// Every request would fail to wait for the rate limit without a burst
+	if auth0RateLimit > 0 && auth0RateLimitBurst < 1 {
+		setupLog.Error(nil, "invalid Auth0 rate limit burst, must be at least 1", "burst", auth0RateLimitBurst)
+		os.Exit(1)
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:                 scheme,
 		Metrics:                metricsserver.Options{BindAddress: metricsAddr},
@@ -101,29 +141,99 @@ func main() {
 		os.Exit(1)
 	}
 
-	domain := mustGetEnv("AUTH0_DOMAIN")
-	clientID := mustGetEnv("AUTH0_CLIENT_ID")
-	clientSecret := mustGetEnv("AUTH0_CLIENT_SECRET")
-
-	auth0Api, err := management.New(
-		domain,
-		management.WithClientCredentials(context.Background(), clientID, clientSecret),
-	)
-
-	if err != nil {
-		setupLog.Error(err, "unable to create auth0 api client")
-		os.Exit(1)
+	// Requests to Auth0 are rate limited across all controllers to avoid
+	// being throttled by the tenant
+	ratelimit.SetLimit(auth0RateLimit, auth0RateLimitBurst)
+
+	tenants := controller.NewTenants(mgr.GetClient(), defaultTenant)
+
+	// Before Auth0Tenants the operator managed a single tenant configured by
+	// environment variables, which resources without a tenantRef still use
+	// until a default tenant is set
+	if defaultTenant == "" && os.Getenv("AUTH0_DOMAIN") != "" {
+		tenants.UseEnvironmentTenant(
+			mustGetEnv("AUTH0_DOMAIN"),
+			mustGetEnv("AUTH0_CLIENT_ID"),
+			mustGetEnv("AUTH0_CLIENT_SECRET"),
+		)
 	}
 
 	if err = (&controller.ClientReconciler{
 		Client:   mgr.GetClient(),
 		Scheme:   mgr.GetScheme(),
 		Recorder: mgr.GetEventRecorderFor("client-controller"),
-		Auth0Api: auth0Api,
+		Tenants:  tenants,
+
+		DefaultDeletionPolicy: defaultDeletionPolicy,
+		SyncPeriod:            syncPeriod,
+		APIReader:             mgr.GetAPIReader(),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Client")
 		os.Exit(1)
 	}
+	if err = (&controller.ResourceServerReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("resourceserver-controller"),
+		Tenants:  tenants,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ResourceServer")
+		os.Exit(1)
+	}
+	if err = (&controller.ClientGrantReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("clientgrant-controller"),
+		Tenants:  tenants,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ClientGrant")
+		os.Exit(1)
+	}
+	if err = (&controller.ConnectionReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("connection-controller"),
+		Tenants:  tenants,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Connection")
+		os.Exit(1)
+	}
+	if err = (&controller.RoleReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("role-controller"),
+		Tenants:  tenants,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Role")
+		os.Exit(1)
+	}
+	if err = (&controller.OrganizationReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("organization-controller"),
+		Tenants:  tenants,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Organization")
+		os.Exit(1)
+	}
+	if err = (&controller.ActionReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("action-controller"),
+		Tenants:  tenants,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Action")
+		os.Exit(1)
+	}
+	if err = (&controller.TriggerBindingReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("triggerbinding-controller"),
+		Tenants:  tenants,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "TriggerBinding")
+		os.Exit(1)
+	}
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
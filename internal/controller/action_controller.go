@@ -0,0 +1,455 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/auth0/go-auth0/management"
+	auth0v1alpha1 "github.com/rgracey/auth0-operator/api/v1alpha1"
+)
+
+// ActionReconciler reconciles a Action object
+type ActionReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	Tenants  *Tenants
+}
+
+// actionBuildPollInterval is how often an action is checked while it's
+// being built before it can be deployed
+const actionBuildPollInterval = 5 * time.Second
+
+// defaultTriggerVersions are the trigger versions used when an Action
+// doesn't specify one
+var defaultTriggerVersions = map[string]string{
+	"post-login":                    "v3",
+	"credentials-exchange":          "v2",
+	"pre-user-registration":         "v2",
+	"post-user-registration":        "v2",
+	"post-change-password":          "v2",
+	"send-phone-message":            "v2",
+	"password-reset-post-challenge": "v1",
+}
+
+//+kubebuilder:rbac:groups=auth0.gracey.io,resources=actions,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=auth0.gracey.io,resources=actions/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=auth0.gracey.io,resources=actions/finalizers,verbs=update
+//+kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch
+//+kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch
+
+// Reconcile moves the Auth0 action towards the state specified by
+// the Action object
+func (r *ActionReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	// Fetch the Action instance
+	instance := &auth0v1alpha1.Action{}
+	if err := r.Get(ctx, req.NamespacedName, instance); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	// Check if the Action instance is being deleted, and run finalizer logic.
+	// This comes before resolving the tenant so that deletion isn't blocked
+	// by a tenant that can no longer be used
+	if instance.IsBeingDeleted() {
+		logger.Info("deleting action", "name", instance.Spec.Name)
+		return ctrl.Result{}, r.handleFinalizer(ctx, instance)
+	}
+
+	// The Action is managed in the Auth0 tenant it references. A finalizer
+	// isn't added until the tenant can be used to clean up after it
+	api, err := r.Tenants.Api(ctx, instance.Namespace, instance.Spec.TenantRef)
+
+	if err != nil {
+		logger.Error(err, "unable to get Auth0 tenant")
+		return ctrl.Result{}, err
+	}
+
+	if !hasFinalizer(instance) {
+		return ctrl.Result{Requeue: true}, addFinalizer(ctx, r.Client, instance)
+	}
+
+	a, secretsHash, err := r.toAuth0Action(ctx, instance)
+
+	// Create the Action if it doesn't exist
+	if instance.Auth0Id() == "" {
+		if err != nil {
+			r.Recorder.Event(instance, "Warning", EventReasonCreateFailed, err.Error())
+			return ctrl.Result{}, err
+		}
+
+		logger.Info("creating action", "name", instance.Spec.Name)
+		err = api.Action.Create(ctx, a)
+
+		if err != nil {
+			logger.Error(err, "unable to create action", "name", instance.Spec.Name)
+			r.Recorder.Event(instance, "Warning", EventReasonCreateFailed, err.Error())
+			return ctrl.Result{}, err
+		}
+
+		logger.Info("created action", "name", instance.Spec.Name, "Auth0 id", a.GetID())
+
+		instance.Status.Auth0Id = a.GetID()
+		instance.Status.SecretsHash = secretsHash
+		apiErr := r.Status().Update(ctx, instance)
+
+		if apiErr != nil {
+			logger.Error(apiErr, "unable to update action status", "name", instance.Spec.Name)
+
+			logger.Info("deleting action", "name", instance.Spec.Name, "Auth0 id", instance.Status.Auth0Id)
+			err = api.Action.Delete(ctx, instance.Status.Auth0Id)
+
+			if err != nil {
+				logger.Error(err, "unable to delete action", "name", instance.Spec.Name)
+				r.Recorder.Event(instance, "Warning", EventReasonDeleteFailed, err.Error())
+			}
+
+			return ctrl.Result{}, apiErr
+		}
+
+		r.Recorder.Event(
+			instance,
+			"Normal",
+			EventReasonCreated,
+			fmt.Sprintf(
+				"Created action %s (ID: %s)",
+				instance.Spec.Name,
+				instance.Status.Auth0Id,
+			),
+		)
+	} else {
+		if err != nil {
+			r.Recorder.Event(instance, "Warning", EventReasonUpdateFailed, err.Error())
+			return ctrl.Result{}, err
+		}
+
+		current, err := api.Action.Read(ctx, instance.Auth0Id())
+
+		if err != nil {
+			logger.Error(err, "unable to fetch action", "name", instance.Spec.Name)
+			r.Recorder.Event(instance, "Warning", EventReasonUpdateFailed, err.Error())
+			return ctrl.Result{}, err
+		}
+
+		// Every update creates a new draft that has to be built, so the
+		// action is only updated when it has changed
+		if !actionUpToDate(current, a) || instance.Status.SecretsHash != secretsHash {
+			logger.Info("updating action", "name", instance.Spec.Name)
+			err = api.Action.Update(ctx, instance.Auth0Id(), a)
+
+			if err != nil {
+				logger.Error(err, "unable to update action", "name", instance.Spec.Name)
+				r.Recorder.Event(instance, "Warning", EventReasonUpdateFailed, err.Error())
+				return ctrl.Result{}, err
+			}
+
+			instance.Status.SecretsHash = secretsHash
+			if err := r.Status().Update(ctx, instance); err != nil {
+				return ctrl.Result{}, err
+			}
+
+			r.Recorder.Event(
+				instance,
+				"Normal",
+				EventReasonUpdated,
+				fmt.Sprintf("Updated action %s", instance.Spec.Name),
+			)
+		}
+	}
+
+	if !instance.Spec.Deploy {
+		return ctrl.Result{}, nil
+	}
+
+	return r.deploy(ctx, api, instance)
+}
+
+// deploy deploys the latest changes to the action once it has been built
+func (r *ActionReconciler) deploy(ctx context.Context, api *management.Management, instance *auth0v1alpha1.Action) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	current, err := api.Action.Read(ctx, instance.Auth0Id())
+
+	if err != nil {
+		logger.Error(err, "unable to fetch action", "name", instance.Spec.Name)
+		r.Recorder.Event(instance, "Warning", EventReasonDeployFailed, err.Error())
+		return ctrl.Result{}, err
+	}
+
+	switch current.GetStatus() {
+	case management.ActionStatusBuilt:
+	case management.ActionStatusFailed:
+		err = fmt.Errorf("action %s failed to build", instance.Spec.Name)
+		r.Recorder.Event(instance, "Warning", EventReasonDeployFailed, err.Error())
+		return ctrl.Result{}, err
+	default:
+		logger.Info("waiting for action to be built", "name", instance.Spec.Name, "status", current.GetStatus())
+		return ctrl.Result{RequeueAfter: actionBuildPollInterval}, nil
+	}
+
+	if current.AllChangesDeployed {
+		if v := current.GetDeployedVersion(); v != nil && v.Number != instance.Status.DeployedVersion {
+			instance.Status.DeployedVersion = v.Number
+			return ctrl.Result{}, r.Status().Update(ctx, instance)
+		}
+
+		return ctrl.Result{}, nil
+	}
+
+	logger.Info("deploying action", "name", instance.Spec.Name)
+	v, err := api.Action.Deploy(ctx, instance.Auth0Id())
+
+	if err != nil {
+		logger.Error(err, "unable to deploy action", "name", instance.Spec.Name)
+		r.Recorder.Event(instance, "Warning", EventReasonDeployFailed, err.Error())
+		return ctrl.Result{}, err
+	}
+
+	instance.Status.DeployedVersion = v.Number
+	if err := r.Status().Update(ctx, instance); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	r.Recorder.Event(
+		instance,
+		"Normal",
+		EventReasonDeployed,
+		fmt.Sprintf(
+			"Deployed version %d of action %s",
+			v.Number,
+			instance.Spec.Name,
+		),
+	)
+
+	return ctrl.Result{}, nil
+}
+
+// toAuth0Action converts an Action to an Auth0 action, loading its code
+// and secrets. A hash of the versions of the Secrets holding the secret
+// values is returned alongside it
+func (r *ActionReconciler) toAuth0Action(
+	ctx context.Context,
+	instance *auth0v1alpha1.Action,
+) (*management.Action, string, error) {
+	code, err := loadConfigMapValue(ctx, r.Client, instance.Namespace, instance.Spec.CodeRef)
+
+	if err != nil {
+		return nil, "", fmt.Errorf("codeRef: %w", err)
+	}
+
+	version := instance.Spec.Trigger.Version
+	if version == "" {
+		version = defaultTriggerVersions[instance.Spec.Trigger.Id]
+	}
+
+	dependencies := make([]management.ActionDependency, 0, len(instance.Spec.Dependencies))
+	for _, d := range instance.Spec.Dependencies {
+		name, version := d.Name, d.Version
+		dependencies = append(dependencies, management.ActionDependency{
+			Name:    &name,
+			Version: &version,
+		})
+	}
+
+	hash := sha256.New()
+	secrets := make([]management.ActionSecret, 0, len(instance.Spec.Secrets))
+	for _, s := range instance.Spec.Secrets {
+		value, version, err := loadVersionedSecretValue(ctx, r.Client, instance.Namespace, s.SecretRef)
+
+		if err != nil {
+			return nil, "", fmt.Errorf("secret %s: %w", s.Name, err)
+		}
+
+		name := s.Name
+		secrets = append(secrets, management.ActionSecret{
+			Name:  &name,
+			Value: &value,
+		})
+
+		// The hash is published in the status, so it's of where the value
+		// came from rather than the value itself
+		fmt.Fprintf(hash, "%s=%s/%s@%s\n", name, s.SecretRef.Name, s.SecretRef.Key, version)
+	}
+
+	a := &management.Action{
+		Name: &instance.Spec.Name,
+		SupportedTriggers: []management.ActionTrigger{
+			{
+				ID:      &instance.Spec.Trigger.Id,
+				Version: &version,
+			},
+		},
+		Code:         &code,
+		Runtime:      &instance.Spec.Runtime,
+		Dependencies: &dependencies,
+		Secrets:      &secrets,
+	}
+
+	return a, hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// actionUpToDate returns true if the current Auth0 action matches desired.
+// Secret values aren't returned by Auth0, so only their names are compared
+func actionUpToDate(current, desired *management.Action) bool {
+	if current.GetName() != desired.GetName() ||
+		current.GetCode() != desired.GetCode() ||
+		current.GetRuntime() != desired.GetRuntime() {
+		return false
+	}
+
+	if len(current.SupportedTriggers) != 1 ||
+		current.SupportedTriggers[0].GetID() != desired.SupportedTriggers[0].GetID() ||
+		current.SupportedTriggers[0].GetVersion() != desired.SupportedTriggers[0].GetVersion() {
+		return false
+	}
+
+	var currentDependencies, desiredDependencies []string
+	for _, d := range current.GetDependencies() {
+		currentDependencies = append(currentDependencies, d.GetName()+"@"+d.GetVersion())
+	}
+	for _, d := range desired.GetDependencies() {
+		desiredDependencies = append(desiredDependencies, d.GetName()+"@"+d.GetVersion())
+	}
+
+	var currentSecrets, desiredSecrets []string
+	for _, s := range current.GetSecrets() {
+		currentSecrets = append(currentSecrets, s.GetName())
+	}
+	for _, s := range desired.GetSecrets() {
+		desiredSecrets = append(desiredSecrets, s.GetName())
+	}
+
+	return sameElements(currentDependencies, desiredDependencies) &&
+		sameElements(currentSecrets, desiredSecrets)
+}
+
+// sameElements returns true if a and b contain the same strings in any order
+func sameElements(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	a = append([]string(nil), a...)
+	b = append([]string(nil), b...)
+	sort.Strings(a)
+	sort.Strings(b)
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// actionsForConfigMap maps a ConfigMap to the Actions whose code it holds
+func (r *ActionReconciler) actionsForConfigMap(ctx context.Context, obj client.Object) []reconcile.Request {
+	return r.actionsMatching(ctx, obj.GetNamespace(), func(a *auth0v1alpha1.Action) bool {
+		return a.Spec.CodeRef.Name == obj.GetName()
+	})
+}
+
+// actionsForSecret maps a Secret to the Actions whose secrets it holds
+func (r *ActionReconciler) actionsForSecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	return r.actionsMatching(ctx, obj.GetNamespace(), func(a *auth0v1alpha1.Action) bool {
+		for _, s := range a.Spec.Secrets {
+			if s.SecretRef.Name == obj.GetName() {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// actionsMatching returns requests for the Actions in the namespace that
+// match the predicate
+func (r *ActionReconciler) actionsMatching(
+	ctx context.Context,
+	namespace string,
+	match func(*auth0v1alpha1.Action) bool,
+) []reconcile.Request {
+	actions := &auth0v1alpha1.ActionList{}
+	if err := r.List(ctx, actions, client.InNamespace(namespace)); err != nil {
+		log.FromContext(ctx).Error(err, "unable to list actions")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for i := range actions.Items {
+		if match(&actions.Items[i]) {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Namespace: actions.Items[i].Namespace,
+					Name:      actions.Items[i].Name,
+				},
+			})
+		}
+	}
+
+	return requests
+}
+
+// actionsForTenantSecret maps a Secret to the Actions managed in the tenants
+// whose credentials it holds
+func (r *ActionReconciler) actionsForTenantSecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	return requestsForTenantSecret(ctx, r.Client, r.Tenants, &auth0v1alpha1.ActionList{}, obj)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ActionReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	err := indexByTenant(mgr, r.Tenants, &auth0v1alpha1.Action{}, func(obj client.Object) *auth0v1alpha1.TenantReference {
+		return obj.(*auth0v1alpha1.Action).Spec.TenantRef
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&auth0v1alpha1.Action{}).
+		Watches(
+			&corev1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(r.actionsForConfigMap),
+		).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.actionsForSecret),
+		).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.actionsForTenantSecret),
+		).
+		Complete(rateLimitAware(r))
+}
@@ -0,0 +1,54 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/auth0/go-auth0/management"
+	auth0v1alpha1 "github.com/rgracey/auth0-operator/api/v1alpha1"
+)
+
+// handleFinalizer handles the finalizer logic for the Action
+func (r *ActionReconciler) handleFinalizer(
+	ctx context.Context,
+	instance *auth0v1alpha1.Action,
+) error {
+	if !hasFinalizer(instance) {
+		return nil
+	}
+
+	if instance.Auth0Id() == "" {
+		return removeFinalizer(ctx, r.Client, instance)
+	}
+
+	api, err := deletionApi(ctx, r.Tenants, r.Recorder, instance, instance.Spec.TenantRef)
+	if err != nil {
+		return err
+	}
+
+	if api == nil {
+		return removeFinalizer(ctx, r.Client, instance)
+	}
+
+	// Auth0 refuses to delete actions that are bound to a trigger unless
+	// forced, which also removes the bindings
+	err = api.Action.Delete(ctx, instance.Status.Auth0Id, management.Parameter("force", "true"))
+
+	if err != nil {
+		r.Recorder.Event(instance, "Warning", EventReasonDeleteFailed, err.Error())
+		return err
+	}
+
+	r.Recorder.Event(
+		instance,
+		"Normal",
+		EventReasonDeleted,
+		fmt.Sprintf(
+			"Deleted action %s (ID: %s)",
+			instance.Spec.Name,
+			instance.Status.Auth0Id,
+		),
+	)
+
+	return removeFinalizer(ctx, r.Client, instance)
+}
@@ -0,0 +1,176 @@
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	auth0v1alpha1 "github.com/rgracey/auth0-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const testActionCode = `exports.onExecuteCredentialsExchange = async (event, api) => {
+  api.accessToken.setCustomClaim("https://example.com/test", event.secrets.TEST_SECRET.length);
+};
+`
+
+var _ = Describe("Action controller", func() {
+	var key types.NamespacedName
+
+	var configMap *corev1.ConfigMap
+
+	var secret *corev1.Secret
+
+	var action *auth0v1alpha1.Action
+
+	BeforeEach(func() {
+		suffix := time.Now().Format("20060102150405")
+
+		key = types.NamespacedName{
+			Name:      "test-action-" + suffix,
+			Namespace: "default",
+		}
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+			},
+			Data: map[string]string{
+				"index.js": testActionCode,
+			},
+		}
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+			},
+			StringData: map[string]string{
+				"test-secret": "test-suite-secret",
+			},
+		}
+		action = &auth0v1alpha1.Action{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+			},
+			Spec: auth0v1alpha1.ActionSpec{
+				Name: "test-suite-" + suffix,
+				Trigger: auth0v1alpha1.ActionTrigger{
+					Id: "credentials-exchange",
+				},
+				CodeRef: auth0v1alpha1.ConfigMapRef{
+					Name: configMap.Name,
+					Key:  "index.js",
+				},
+				Runtime: "node18",
+				Dependencies: []auth0v1alpha1.ActionDependency{
+					{Name: "lodash", Version: "4.17.21"},
+				},
+				Secrets: []auth0v1alpha1.ActionSecret{
+					{
+						Name: "TEST_SECRET",
+						SecretRef: auth0v1alpha1.SecretRef{
+							Name: secret.Name,
+							Key:  "test-secret",
+						},
+					},
+				},
+				Deploy: true,
+			},
+		}
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.Delete(context.Background(), action)).To(Succeed())
+		Expect(k8sClient.Delete(context.Background(), configMap)).To(Succeed())
+		Expect(k8sClient.Delete(context.Background(), secret)).To(Succeed())
+
+		Eventually(func() bool {
+			err := k8sClient.Get(ctx, key, &auth0v1alpha1.Action{})
+			return ctrlclient.IgnoreNotFound(err) == nil
+		}).WithTimeout(timeout).Should(BeTrue())
+	})
+
+	Describe("when an action is created", func() {
+		JustBeforeEach(func() {
+			Expect(k8sClient.Create(ctx, configMap)).To(Succeed())
+			Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+			Expect(k8sClient.Create(ctx, action)).To(Succeed())
+
+			Eventually(func() bool {
+				if err := k8sClient.Get(ctx, key, action); err != nil {
+					return false
+				}
+				return action.Status.Auth0Id != ""
+			}).WithTimeout(timeout).Should(BeTrue())
+		})
+
+		It("should create an action in Auth0 with the code from the ConfigMap", func() {
+			a, err := auth0Api.Action.Read(ctx, action.Status.Auth0Id)
+			Expect(err).To(BeNil())
+			Expect(a.GetName()).To(Equal(action.Spec.Name))
+			Expect(a.GetCode()).To(Equal(testActionCode))
+			Expect(a.GetRuntime()).To(Equal("node18"))
+			Expect(a.SupportedTriggers).To(HaveLen(1))
+			Expect(a.SupportedTriggers[0].GetID()).To(Equal("credentials-exchange"))
+			Expect(a.GetSecrets()).To(HaveLen(1))
+			Expect(a.GetSecrets()[0].GetName()).To(Equal("TEST_SECRET"))
+		})
+
+		It("should deploy the action once it has been built", func() {
+			Eventually(func() int {
+				if err := k8sClient.Get(ctx, key, action); err != nil {
+					return 0
+				}
+				return action.Status.DeployedVersion
+			}).WithTimeout(2 * time.Minute).WithPolling(time.Second).ShouldNot(BeZero())
+
+			a, err := auth0Api.Action.Read(ctx, action.Status.Auth0Id)
+			Expect(err).To(BeNil())
+			Expect(a.AllChangesDeployed).To(BeTrue())
+		})
+
+		When("the code in the ConfigMap is changed", func() {
+			It("should update the action in Auth0", func() {
+				updatedCode := "exports.onExecuteCredentialsExchange = async (event, api) => {};\n"
+
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: configMap.Name, Namespace: configMap.Namespace}, configMap)).To(Succeed())
+				configMap.Data["index.js"] = updatedCode
+				Expect(k8sClient.Update(ctx, configMap)).To(Succeed())
+
+				Eventually(func() string {
+					a, err := auth0Api.Action.Read(ctx, action.Status.Auth0Id)
+					if err != nil {
+						return ""
+					}
+					return a.GetCode()
+				}).WithTimeout(timeout).WithPolling(time.Second).Should(Equal(updatedCode))
+			})
+		})
+
+		When("the value of a secret is changed", func() {
+			It("should update the action without publishing the value", func() {
+				previousHash := action.Status.SecretsHash
+
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}, secret)).To(Succeed())
+				secret.StringData = map[string]string{"test-secret": "test-suite-secret-changed"}
+				Expect(k8sClient.Update(ctx, secret)).To(Succeed())
+
+				Eventually(func() (string, error) {
+					err := k8sClient.Get(ctx, key, action)
+					return action.Status.SecretsHash, err
+				}).WithTimeout(timeout).ShouldNot(Equal(previousHash))
+
+				// The hash is of the version of the Secret, not its value
+				valueHash := sha256.Sum256([]byte("TEST_SECRET=test-suite-secret-changed\n"))
+				Expect(action.Status.SecretsHash).ToNot(Equal(hex.EncodeToString(valueHash[:])))
+			})
+		})
+	})
+})
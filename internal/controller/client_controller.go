@@ -19,13 +19,22 @@ package controller
 import (
 	"context"
 	"fmt"
+	"strings"
+	"sync"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/auth0/go-auth0/management"
 	auth0v1alpha1 "github.com/rgracey/auth0-operator/api/v1alpha1"
@@ -36,7 +45,22 @@ type ClientReconciler struct {
 	client.Client
 	Scheme   *runtime.Scheme
 	Recorder record.EventRecorder
-	Auth0Api *management.Management
+	Tenants  *Tenants
+
+	// The deletion policy of Clients that don't specify one. Defaults to
+	// Delete if empty
+	DefaultDeletionPolicy string
+
+	// How often Clients that don't specify a sync interval are synced with
+	// Auth0. Periodic syncs are disabled if zero
+	SyncPeriod time.Duration
+
+	// Reads generated secrets that the cache hasn't caught up with yet
+	APIReader client.Reader
+
+	// The resource version of the generated secret last written for each
+	// Client, keyed by its UID, until the cache catches up with it
+	generatedSecretVersions sync.Map
 }
 
 const (
@@ -46,23 +70,41 @@ const (
 	EventReasonUpdateFailed = "UpdateFailed"
 	EventReasonDeleted      = "Deleted"
 	EventReasonDeleteFailed = "DeleteFailed"
+	EventReasonDeployed     = "Deployed"
+	EventReasonDeployFailed = "DeployFailed"
+	EventReasonAdopted      = "Adopted"
+	EventReasonAdoptFailed  = "AdoptFailed"
+	EventReasonOrphaned     = "Orphaned"
+	EventReasonDrifted      = "Drifted"
+	EventReasonNotFound     = "NotFound"
+	EventReasonRotated      = "Rotated"
+	EventReasonRotateFailed = "RotateFailed"
+
+	EventReasonRotationUnsupported = "RotationUnsupported"
+	EventReasonDuplicate           = "Duplicate"
 )
 
+// clientSecretRefIndex indexes Clients by the name of the Secret holding
+// their client secret
+const clientSecretRefIndex = ".spec.clientSecret.secretRef.name"
+
+// clientAuth0IdIndex indexes Clients by the ID of the Auth0 client they
+// manage
+const clientAuth0IdIndex = ".status.auth0Id"
+
 //+kubebuilder:rbac:groups=auth0.gracey.io,resources=clients,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=auth0.gracey.io,resources=clients/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=auth0.gracey.io,resources=clients/finalizers,verbs=update
 //+kubebuilder:rbac:groups=auth0.gracey.io,resources=events,verbs=create;patch
 //+kubebuilder:rbac:groups=core,resources=secrets,verbs=get;watch;create;update;patch;delete
-
-// Reconcile is part of the main kubernetes reconciliation loop which aims to
-// move the current state of the cluster closer to the desired state.
-// TODO(user): Modify the Reconcile function to compare the state specified by
-// the Client object against the actual cluster state, and then
-// perform operations to make the cluster state reflect the state specified by
-// the user.
-//
-// For more details, check Reconcile and its Result here:
-// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.16.3/pkg/reconcile
+//+kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile moves the Auth0 client towards the state specified by the
+// Client object. Deleted Clients are cleaned up first. Otherwise the Client
+// is adopted or created in its tenant, or the fields that differ from the
+// spec are updated, before its secret is rotated when due and its
+// credentials are written to the requested outputs. The outcome is recorded
+// in the Client's status, and the Client is requeued to be synced again
 func (r *ClientReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
@@ -72,22 +114,107 @@ func (r *ClientReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
-	if !r.hasFinalizer(instance) {
-		return ctrl.Result{Requeue: true}, r.addFinalizer(instance)
-	}
-
-	// Check if the Client instance is being deleted, and run finalizer logic
+	// Check if the Client instance is being deleted, and run finalizer logic.
+	// This comes before resolving the tenant so that deletion isn't blocked
+	// by a tenant that can no longer be used
 	if instance.IsBeingDeleted() {
 		logger.Info("deleting client", "name", instance.Spec.Name)
+		r.generatedSecretVersions.Delete(instance.UID)
 		return ctrl.Result{}, r.handleFinalizer(ctx, instance)
 	}
 
+	// The Client is managed in the Auth0 tenant it references. A finalizer
+	// isn't added until the tenant can be used to clean up after it
+	api, err := r.Tenants.Api(ctx, instance.Namespace, instance.Spec.TenantRef)
+
+	if err != nil {
+		logger.Error(err, "unable to get Auth0 tenant")
+		setSyncedCondition(instance, metav1.ConditionFalse, ConditionReasonTenantUnavailable, err.Error())
+
+		if statusErr := r.updateStatus(ctx, instance, err); statusErr != nil {
+			logger.Error(statusErr, "unable to update client status", "name", instance.Spec.Name)
+		}
+
+		return ctrl.Result{}, err
+	}
+
+	if !hasFinalizer(instance) {
+		return ctrl.Result{Requeue: true}, addFinalizer(ctx, r.Client, instance)
+	}
+
+	result, err := r.reconcileClient(ctx, api, instance)
+
+	// Auth0 can't be watched for changes, so the client is synced
+	// periodically to revert changes made outside of the operator
+	if err == nil && result.IsZero() {
+		result.RequeueAfter = r.syncInterval(instance)
+
+		// Rotations and the end of their overlap can't wait for a sync
+		if d := rotationRequeueAfter(instance, time.Now()); d > 0 &&
+			(result.RequeueAfter <= 0 || d < result.RequeueAfter) {
+			result.RequeueAfter = d
+		}
+	}
+
+	if statusErr := r.updateStatus(ctx, instance, err); statusErr != nil {
+		logger.Error(statusErr, "unable to update client status", "name", instance.Spec.Name)
+
+		if err == nil {
+			err = statusErr
+		}
+	}
+
+	return result, err
+}
+
+// syncInterval returns the sync interval of the Client, falling back to the
+// sync period of the reconciler
+func (r *ClientReconciler) syncInterval(instance *auth0v1alpha1.Client) time.Duration {
+	if instance.Spec.SyncInterval != nil {
+		return instance.Spec.SyncInterval.Duration
+	}
+
+	return r.SyncPeriod
+}
+
+// reconcileClient moves the Auth0 client towards the state specified by the
+// Client, recording the outcome of each step in the Client's conditions
+func (r *ClientReconciler) reconcileClient(
+	ctx context.Context,
+	api *management.Management,
+	instance *auth0v1alpha1.Client,
+) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	// Adopt an existing client rather than creating a new one
+	if instance.Auth0Id() == "" && instance.AdoptAuth0Id() != "" {
+		if err := r.adoptClient(ctx, api, instance); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
 	var clientSecret, err = r.maybeLoadSecretValue(ctx, instance)
 
 	if err != nil {
+		setSyncedCondition(instance, metav1.ConditionFalse, ConditionReasonSecretLoadFailed, err.Error())
 		return ctrl.Result{}, err
 	}
 
+	if !instance.CanRotateSecret() && instance.RotationRequested() {
+		r.rejectRotation(instance)
+	}
+
+	// Generated secrets are rotated by generating a new one, which is then
+	// pushed to Auth0 like any other change to the secret
+	rotated := false
+	if instance.GeneratesSecret() && instance.Auth0Id() != "" && rotationDue(instance, time.Now()) {
+		if clientSecret, err = r.rotateGeneratedSecret(ctx, instance); err != nil {
+			return ctrl.Result{}, err
+		}
+
+		rotated = true
+	}
+
 	// callbackUrls must be non-nil
 	if instance.Spec.CallbackUrls == nil {
 		instance.Spec.CallbackUrls = []string{}
@@ -106,33 +233,74 @@ func (r *ClientReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 		(*c.ClientMetadata)[k] = v
 	}
 
+	// Tag the client with the Client that owns it so it can be found again
+	// if the Client's status can't be updated after creating it. There's no
+	// room for the tag if the spec uses every key Auth0 allows
+	if len(instance.Spec.Metadata) < auth0v1alpha1.MaxMetadataKeys {
+		(*c.ClientMetadata)[auth0v1alpha1.OwnerMetadataKey] = string(instance.UID)
+	}
+
+	// Reuse a client created by a previous reconcile whose status update
+	// failed, rather than creating a duplicate. Only a create that may have
+	// succeeded is looked for, as finding it means listing every client
+	if instance.Auth0Id() == "" && instance.Status.PendingCreate {
+		existing, err := r.findOwnedClient(ctx, api, instance)
+
+		if err != nil {
+			logger.Error(err, "unable to search for existing client", "name", instance.Spec.Name)
+			r.Recorder.Event(instance, "Warning", EventReasonCreateFailed, err.Error())
+			setSyncedCondition(instance, metav1.ConditionFalse, EventReasonCreateFailed, err.Error())
+			return ctrl.Result{}, err
+		}
+
+		if existing != nil {
+			logger.Info("found existing client", "name", instance.Spec.Name, "Auth0 id", existing.GetClientID())
+			instance.Status.Auth0Id = existing.GetClientID()
+		}
+
+		instance.Status.PendingCreate = false
+	}
+
 	// Create the Client if it doesn't exist
 	if instance.Auth0Id() == "" {
+		// Record that a create is pending first, so the client is looked for
+		// if its ID can't be recorded after creating it
+		instance.Status.PendingCreate = true
+
+		if err := r.Status().Update(ctx, instance); err != nil {
+			logger.Error(err, "unable to update client status", "name", instance.Spec.Name)
+			setSyncedCondition(instance, metav1.ConditionFalse, EventReasonCreateFailed, err.Error())
+			return ctrl.Result{}, err
+		}
+
 		logger.Info("creating client", "name", instance.Spec.Name)
-		err := r.Auth0Api.Client.Create(ctx, c)
+		err := api.Client.Create(ctx, c)
 
 		if err != nil {
 			logger.Error(err, "unable to create client", "name", instance.Spec.Name)
 			r.Recorder.Event(instance, "Warning", EventReasonCreateFailed, err.Error())
+			setSyncedCondition(instance, metav1.ConditionFalse, EventReasonCreateFailed, err.Error())
+
+			// Auth0 rejected the request, so no client was created
+			if isClientError(err) {
+				instance.Status.PendingCreate = false
+			}
+
 			return ctrl.Result{}, err
 		}
 
 		logger.Info("created client", "name", instance.Spec.Name, "Auth0 id", c.GetClientID())
 
+		// If this fails the client is found by its owner metadata next time
 		instance.Status.Auth0Id = c.GetClientID()
+		instance.Status.PendingCreate = false
 		apiErr := r.Status().Update(ctx, instance)
 
 		if apiErr != nil {
 			logger.Error(apiErr, "unable to update client status", "name", instance.Spec.Name)
-
-			logger.Info("deleting client", "name", instance.Spec.Name, "Auth0 id", instance.Status.Auth0Id)
-			err = r.Auth0Api.Client.Delete(ctx, instance.Status.Auth0Id)
-
-			if err != nil {
-				logger.Error(err, "unable to delete client", "name", instance.Spec.Name)
-				r.Recorder.Event(instance, "Warning", EventReasonDeleteFailed, err.Error())
-			}
-
+			instance.Status.Auth0Id = ""
+			instance.Status.PendingCreate = true
+			setSyncedCondition(instance, metav1.ConditionFalse, EventReasonCreateFailed, apiErr.Error())
 			return ctrl.Result{}, apiErr
 		}
 
@@ -147,134 +315,406 @@ func (r *ClientReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 			),
 		)
 
+		setSyncedCondition(instance, metav1.ConditionTrue, EventReasonCreated, "Client created in Auth0")
+
 		return ctrl.Result{Requeue: true}, nil
 	}
 
-	c, err = r.Auth0Api.Client.Read(ctx, instance.Status.Auth0Id)
+	current, err := api.Client.Read(ctx, instance.Status.Auth0Id)
+
+	if isNotFound(err) {
+		return r.handleNotFound(ctx, instance)
+	}
 
 	if err != nil {
 		logger.Error(err, "unable to fetch client", "name", instance.Spec.Name)
 		r.Recorder.Event(instance, "Warning", EventReasonUpdateFailed, err.Error())
+		setSyncedCondition(instance, metav1.ConditionFalse, EventReasonUpdateFailed, err.Error())
 		return ctrl.Result{}, err
 	}
 
-	if instance.ShouldOutputSecret() {
-		if err := r.upsertOutputSecret(ctx, instance, c.GetClientSecret()); err != nil {
+	if instance.AdoptAuth0Id() != "" {
+		keepUnsetFields(instance, c, current)
+	}
+
+	// Only the fields that differ from the spec are sent to Auth0
+	patch, changed := diffClient(c, current)
+
+	// The spec hasn't changed since it was last synced, so any differences
+	// are changes made outside of the operator, e.g. in the dashboard
+	drifted := []string(nil)
+	if instance.Generation == instance.Status.ObservedGeneration {
+		for _, field := range changed {
+			// A rotated secret was changed by the operator
+			if rotated && field == "clientSecret" {
+				continue
+			}
+
+			// Tagging or untagging the client with its owner is done by the
+			// operator, e.g. for clients created before it tagged them
+			if field == "metadata" && onlyOwnerMetadataChanged(c.GetClientMetadata(), current.GetClientMetadata()) {
+				continue
+			}
+
+			drifted = append(drifted, field)
+		}
+	}
+	instance.Status.DriftedFields = drifted
+
+	if len(changed) > 0 {
+		logger.Info("updating client", "name", instance.Spec.Name, "fields", changed)
+		err = api.Client.Update(ctx, instance.Auth0Id(), patch)
+
+		if err != nil {
+			logger.Error(err, "unable to update client", "name", instance.Spec.Name)
+			r.Recorder.Event(instance, "Warning", EventReasonUpdateFailed, err.Error())
+			setSyncedCondition(instance, metav1.ConditionFalse, EventReasonUpdateFailed, err.Error())
 			return ctrl.Result{}, err
 		}
+
+		if len(drifted) > 0 {
+			r.Recorder.Event(
+				instance,
+				"Warning",
+				EventReasonDrifted,
+				fmt.Sprintf(
+					"Reverted changes made outside of the operator to client %s (ID: %s): %s",
+					instance.Spec.Name,
+					instance.Status.Auth0Id,
+					strings.Join(drifted, ", "),
+				),
+			)
+		} else {
+			r.Recorder.Event(
+				instance,
+				"Normal",
+				EventReasonUpdated,
+				fmt.Sprintf(
+					"Updated client %s (ID: %s): %s",
+					instance.Spec.Name,
+					instance.Status.Auth0Id,
+					strings.Join(changed, ", "),
+				),
+			)
+		}
 	}
 
-	// Auth0 doesn't allow updating these fields
-	c.ClientID = nil
-	c.SigningKeys = nil
-	c.JWTConfiguration.SecretEncoded = nil
+	setSyncedCondition(instance, metav1.ConditionTrue, EventReasonUpdated, "Client is in sync with Auth0")
 
-	// Move Client to the desired state
-	err = r.Auth0Api.Client.Update(ctx, instance.Auth0Id(), c)
+	// The secret sent to Auth0 takes precedence over the one read before it
+	// was updated. Secrets generated by Auth0 are rotated in Auth0
+	outputSecret := current.GetClientSecret()
+	if clientSecret != nil {
+		outputSecret = *clientSecret
+	} else if rotationDue(instance, time.Now()) {
+		if outputSecret, err = r.rotateSecret(ctx, api, instance); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
 
-	if err != nil {
-		logger.Error(err, "unable to update client", "name", instance.Spec.Name)
-		r.Recorder.Event(instance, "Warning", EventReasonUpdateFailed, err.Error())
+	if err := r.reconcileOutputs(ctx, instance, outputSecret); err != nil {
 		return ctrl.Result{}, err
 	}
 
-	// TODO - Raise Updated event
-
 	return ctrl.Result{}, nil
 }
 
-// maybeLoadSecretValue attempts to load a secret from a secret first,
-// then a literal value if the secret doesn't exist
-func (r *ClientReconciler) maybeLoadSecretValue(
+// findOwnedClient returns the Auth0 client tagged with the UID of the
+// Client, or nil if there isn't one
+func (r *ClientReconciler) findOwnedClient(
 	ctx context.Context,
+	api *management.Management,
 	instance *auth0v1alpha1.Client,
-) (*string, error) {
-	if instance.Spec.ClientSecret.SecretRef.Name != "" {
-		secretRef := instance.Spec.ClientSecret.SecretRef
-
-		secret := &corev1.Secret{}
-		err := r.Get(
+) (*management.Client, error) {
+	for page := 0; ; page++ {
+		list, err := api.Client.List(
 			ctx,
-			client.ObjectKey{
-				Namespace: instance.Namespace,
-				Name:      secretRef.Name,
-			},
-			secret,
+			management.Page(page),
+			management.IncludeFields("client_id", "client_metadata"),
 		)
 
 		if err != nil {
 			return nil, err
 		}
 
-		value, ok := secret.Data[secretRef.Key]
-
-		if !ok {
-			return nil, fmt.Errorf(
-				"clientSecret \"%s\" secretRef didn't contain key \"%s\"",
-				secretRef.Name,
-				secretRef.Key,
-			)
+		for _, c := range list.Clients {
+			if c.GetClientMetadata()[auth0v1alpha1.OwnerMetadataKey] == string(instance.UID) {
+				return c, nil
+			}
 		}
 
-		valueStr := string(value)
-		return &valueStr, nil
+		if !list.HasNext() {
+			return nil, nil
+		}
 	}
+}
 
-	if instance.Spec.ClientSecret.Literal != "" {
-		return &instance.Spec.ClientSecret.Literal, nil
+// handleNotFound handles the Auth0 client of the Client no longer existing,
+// either forgetting it so that it's recreated or reporting it in the Synced
+// condition
+func (r *ClientReconciler) handleNotFound(
+	ctx context.Context,
+	instance *auth0v1alpha1.Client,
+) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	message := fmt.Sprintf(
+		"Client %s (ID: %s) no longer exists in Auth0",
+		instance.Spec.Name,
+		instance.Status.Auth0Id,
+	)
+
+	// Adopted clients can't be recreated as their ID is set by the user
+	if instance.Spec.NotFoundPolicy == auth0v1alpha1.NotFoundPolicyReport || instance.AdoptAuth0Id() != "" {
+		logger.Info("client not found", "name", instance.Spec.Name, "Auth0 id", instance.Status.Auth0Id)
+		r.Recorder.Event(instance, "Warning", EventReasonNotFound, message)
+		setSyncedCondition(instance, metav1.ConditionFalse, ConditionReasonNotFound, message)
+		return ctrl.Result{}, nil
 	}
 
-	return nil, nil
+	logger.Info("recreating client", "name", instance.Spec.Name, "Auth0 id", instance.Status.Auth0Id)
+	r.Recorder.Event(instance, "Warning", EventReasonNotFound, message+", recreating it")
+
+	instance.Status.Auth0Id = ""
+	setSyncedCondition(instance, metav1.ConditionFalse, ConditionReasonNotFound, message)
+
+	return ctrl.Result{Requeue: true}, nil
 }
 
-// upsertOutputSecret creates or updates the output secret for a client
-func (r *ClientReconciler) upsertOutputSecret(
+// adoptClient starts managing the existing Auth0 client the Client refers
+// to by recording its ID
+func (r *ClientReconciler) adoptClient(
 	ctx context.Context,
+	api *management.Management,
 	instance *auth0v1alpha1.Client,
-	clientSecret string,
 ) error {
-	secretRef := instance.Spec.ClientSecret.OutputSecretRef
+	logger := log.FromContext(ctx)
+	id := instance.AdoptAuth0Id()
 
-	secret := &corev1.Secret{
-		ObjectMeta: ctrl.ObjectMeta{
-			Namespace: instance.Namespace,
-			Name:      secretRef.Name,
-		},
-		StringData: map[string]string{
-			secretRef.Key: clientSecret,
-		},
+	logger.Info("adopting client", "name", instance.Spec.Name, "Auth0 id", id)
+	c, err := api.Client.Read(ctx, id)
+
+	if err == nil {
+		err = r.checkAdoptable(ctx, instance, c)
 	}
 
-	err := r.Get(
-		ctx,
-		client.ObjectKey{
-			Namespace: instance.Namespace,
-			Name:      secretRef.Name,
-		},
-		secret,
+	if err != nil {
+		logger.Error(err, "unable to adopt client", "name", instance.Spec.Name, "Auth0 id", id)
+		r.Recorder.Event(instance, "Warning", EventReasonAdoptFailed, err.Error())
+		setSyncedCondition(instance, metav1.ConditionFalse, EventReasonAdoptFailed, err.Error())
+		return err
+	}
+
+	instance.Status.Auth0Id = c.GetClientID()
+
+	if err := r.Status().Update(ctx, instance); err != nil {
+		logger.Error(err, "unable to update client status", "name", instance.Spec.Name)
+		instance.Status.Auth0Id = ""
+		return err
+	}
+
+	r.Recorder.Event(
+		instance,
+		"Normal",
+		EventReasonAdopted,
+		fmt.Sprintf(
+			"Adopted client %s (ID: %s)",
+			c.GetName(),
+			instance.Status.Auth0Id,
+		),
 	)
 
-	if err == nil {
-		secret.StringData = map[string]string{}
-		secret.StringData[secretRef.Key] = clientSecret
-		return r.Update(ctx, secret)
+	return nil
+}
+
+// checkAdoptable returns an error if the Client mustn't adopt the Auth0
+// client, because the operator uses it to manage the tenant or because it's
+// already managed by another Client
+func (r *ClientReconciler) checkAdoptable(
+	ctx context.Context,
+	instance *auth0v1alpha1.Client,
+	c *management.Client,
+) error {
+	managementClientId, err := r.Tenants.ClientId(ctx, instance.Namespace, instance.Spec.TenantRef)
+
+	if err != nil {
+		return err
+	}
+
+	if c.GetClientID() == managementClientId {
+		return fmt.Errorf("client %s is used by the operator to manage the tenant and can't be adopted", c.GetClientID())
+	}
+
+	if owner, ok := c.GetClientMetadata()[auth0v1alpha1.OwnerMetadataKey]; ok && owner != string(instance.UID) {
+		return fmt.Errorf("client %s is managed by another Client (UID: %s)", c.GetClientID(), owner)
+	}
+
+	clients := &auth0v1alpha1.ClientList{}
+	if err := r.List(ctx, clients, client.MatchingFields{clientAuth0IdIndex: c.GetClientID()}); err != nil {
+		return err
+	}
+
+	for _, other := range clients.Items {
+		if other.UID != instance.UID {
+			return fmt.Errorf("client %s is already managed by Client %s/%s", c.GetClientID(), other.Namespace, other.Name)
+		}
+	}
+
+	return nil
+}
+
+// maybeLoadSecretValue attempts to load a secret from a secret first,
+// then a literal value if the secret doesn't exist
+func (r *ClientReconciler) maybeLoadSecretValue(
+	ctx context.Context,
+	instance *auth0v1alpha1.Client,
+) (*string, error) {
+	if instance.Spec.ClientSecret.SecretRef.Name != "" {
+		value, err := loadSecretValue(
+			ctx,
+			r.Client,
+			instance.Namespace,
+			instance.Spec.ClientSecret.SecretRef,
+		)
+
+		if err != nil {
+			return nil, fmt.Errorf("clientSecret secretRef: %w", err)
+		}
+
+		return &value, nil
+	}
+
+	if instance.Spec.ClientSecret.Literal != "" {
+		return &instance.Spec.ClientSecret.Literal, nil
 	}
 
-	if client.IgnoreNotFound(err) == nil {
-		if err = ctrl.SetControllerReference(instance, secret, r.Scheme); err != nil {
-			return err
+	if instance.GeneratesSecret() {
+		value, err := r.loadGeneratedSecret(ctx, instance)
+
+		if err != nil {
+			return nil, fmt.Errorf("clientSecret generatedSecretRef: %w", err)
 		}
 
-		return r.Create(ctx, secret)
+		return &value, nil
+	}
+
+	return nil, nil
+}
+
+// clientsForTenantSecret maps a Secret to the Clients managed in the tenants
+// whose credentials it holds
+func (r *ClientReconciler) clientsForTenantSecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	return requestsForTenantSecret(ctx, r.Client, r.Tenants, &auth0v1alpha1.ClientList{}, obj)
+}
+
+// clientsForSecretRef maps a Secret to the Clients whose client secret it
+// holds
+func (r *ClientReconciler) clientsForSecretRef(ctx context.Context, obj client.Object) []reconcile.Request {
+	clients := &auth0v1alpha1.ClientList{}
+	err := r.List(
+		ctx,
+		clients,
+		client.InNamespace(obj.GetNamespace()),
+		client.MatchingFields{clientSecretRefIndex: obj.GetName()},
+	)
+
+	if err != nil {
+		log.FromContext(ctx).Error(err, "unable to list clients")
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(clients.Items))
+	for _, c := range clients.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: c.Namespace,
+				Name:      c.Name,
+			},
+		})
 	}
 
-	return err
+	return requests
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *ClientReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Index Clients by the Secret holding their client secret, so they can
+	// be found when it changes
+	err := mgr.GetFieldIndexer().IndexField(
+		context.Background(),
+		&auth0v1alpha1.Client{},
+		clientSecretRefIndex,
+		func(obj client.Object) []string {
+			instance := obj.(*auth0v1alpha1.Client)
+
+			// Generated secrets may be restored from a backup without an
+			// owner reference, so they're watched like any other
+			name := instance.Spec.ClientSecret.SecretRef.Name
+			if instance.GeneratesSecret() {
+				name = instance.Spec.ClientSecret.GeneratedSecretRef.Name
+			}
+
+			if name == "" {
+				return nil
+			}
+
+			return []string{name}
+		},
+	)
+
+	if err != nil {
+		return err
+	}
+
+	err = mgr.GetFieldIndexer().IndexField(
+		context.Background(),
+		&auth0v1alpha1.Client{},
+		clientAuth0IdIndex,
+		func(obj client.Object) []string {
+			id := obj.(*auth0v1alpha1.Client).Status.Auth0Id
+			if id == "" {
+				return nil
+			}
+
+			return []string{id}
+		},
+	)
+
+	if err != nil {
+		return err
+	}
+
+	err = indexByTenant(mgr, r.Tenants, &auth0v1alpha1.Client{}, func(obj client.Object) *auth0v1alpha1.TenantReference {
+		return obj.(*auth0v1alpha1.Client).Spec.TenantRef
+	})
+
+	if err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&auth0v1alpha1.Client{}).
+		// Status updates don't change the generation, so they don't trigger
+		// another reconcile. Annotations are used to adopt clients
+		For(
+			&auth0v1alpha1.Client{},
+			builder.WithPredicates(predicate.Or(
+				predicate.GenerationChangedPredicate{},
+				predicate.AnnotationChangedPredicate{},
+			)),
+		).
 		Owns(&corev1.Secret{}).
-		Complete(r)
+		Owns(&corev1.ConfigMap{}).
+		// The new client secret is pushed to Auth0 as soon as the Secret
+		// holding it changes
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.clientsForSecretRef),
+		).
+		// Clients are reconciled with the new credentials as soon as the
+		// credentials of their tenant change
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.clientsForTenantSecret),
+		).
+		Complete(rateLimitAware(r))
 }
@@ -0,0 +1,132 @@
+package controller
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/auth0/go-auth0/management"
+	auth0v1alpha1 "github.com/rgracey/auth0-operator/api/v1alpha1"
+)
+
+// diffClient compares the managed fields of the desired client with the
+// current Auth0 client. It returns a client holding only the fields that
+// differ, along with their names as they appear in the Client spec
+func diffClient(desired, current *management.Client) (*management.Client, []string) {
+	patch := &management.Client{}
+	var changed []string
+
+	if desired.GetName() != current.GetName() {
+		patch.Name = desired.Name
+		changed = append(changed, "name")
+	}
+
+	if desired.GetDescription() != current.GetDescription() {
+		patch.Description = desired.Description
+		changed = append(changed, "description")
+	}
+
+	if desired.GetAppType() != "" && desired.GetAppType() != current.GetAppType() {
+		patch.AppType = desired.AppType
+		changed = append(changed, "type")
+	}
+
+	if !equalStrings(desired.GetCallbacks(), current.GetCallbacks()) {
+		patch.Callbacks = desired.Callbacks
+		changed = append(changed, "callbackUrls")
+	}
+
+	if metadata, ok := diffClientMetadata(desired.GetClientMetadata(), current.GetClientMetadata()); !ok {
+		patch.ClientMetadata = &metadata
+		changed = append(changed, "metadata")
+	}
+
+	// The secret is only managed if one is supplied
+	if desired.ClientSecret != nil && desired.GetClientSecret() != current.GetClientSecret() {
+		patch.ClientSecret = desired.ClientSecret
+		changed = append(changed, "clientSecret")
+	}
+
+	return patch, changed
+}
+
+// keepUnsetFields leaves the fields of an adopted client that its spec
+// doesn't set as they are in Auth0, rather than clearing them. The client
+// was configured before it was adopted, e.g. in the dashboard
+func keepUnsetFields(instance *auth0v1alpha1.Client, desired, current *management.Client) {
+	if instance.Spec.Description == "" {
+		desired.Description = current.Description
+	}
+
+	if len(instance.Spec.CallbackUrls) == 0 {
+		desired.Callbacks = current.Callbacks
+	}
+
+	if len(instance.Spec.Metadata) == 0 {
+		for k, v := range current.GetClientMetadata() {
+			if _, ok := (*desired.ClientMetadata)[k]; !ok {
+				(*desired.ClientMetadata)[k] = v
+			}
+		}
+
+		// Like in the spec, the owner tag only fits if there's room for it
+		if len(withoutOwner(*desired.ClientMetadata)) >= auth0v1alpha1.MaxMetadataKeys {
+			delete(*desired.ClientMetadata, auth0v1alpha1.OwnerMetadataKey)
+		}
+	}
+}
+
+// diffClientMetadata returns true if the current metadata matches the
+// desired metadata. Otherwise it returns the metadata to send to Auth0,
+// which removes keys that aren't desired by setting them to null
+func diffClientMetadata(desired, current map[string]interface{}) (map[string]interface{}, bool) {
+	patch := map[string]interface{}{}
+	equal := true
+
+	for k, v := range desired {
+		patch[k] = v
+
+		if fmt.Sprint(current[k]) != fmt.Sprint(v) || current[k] == nil {
+			equal = false
+		}
+	}
+
+	for k := range current {
+		if _, ok := desired[k]; !ok {
+			patch[k] = nil
+			equal = false
+		}
+	}
+
+	return patch, equal
+}
+
+// onlyOwnerMetadataChanged returns true if the desired and current metadata
+// only differ by the key recording the owner of the client
+func onlyOwnerMetadataChanged(desired, current map[string]interface{}) bool {
+	_, equal := diffClientMetadata(withoutOwner(desired), withoutOwner(current))
+	return equal
+}
+
+// withoutOwner returns a copy of metadata without the key recording the
+// owner of the client
+func withoutOwner(metadata map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(metadata))
+
+	for k, v := range metadata {
+		if k != auth0v1alpha1.OwnerMetadataKey {
+			result[k] = v
+		}
+	}
+
+	return result
+}
+
+// equalStrings returns true if a and b contain the same strings in the same
+// order. nil and empty slices are equal
+func equalStrings(a, b []string) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+
+	return reflect.DeepEqual(a, b)
+}
@@ -4,50 +4,72 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/auth0/go-auth0/management"
 	auth0v1alpha1 "github.com/rgracey/auth0-operator/api/v1alpha1"
-	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
-const (
-	finalizerName = "finalizer.auth0.gracey.io"
-)
+// handleFinalizer handles the finalizer logic for the Client
+func (r *ClientReconciler) handleFinalizer(
+	ctx context.Context,
+	instance *auth0v1alpha1.Client,
+) error {
+	if !hasFinalizer(instance) {
+		return nil
+	}
 
-// hasFinalizer returns true if the Client has the finalizer. False otherwise
-func (r *ClientReconciler) hasFinalizer(instance *auth0v1alpha1.Client) bool {
-	return controllerutil.ContainsFinalizer(instance, finalizerName)
-}
+	if instance.Auth0Id() == "" {
+		return removeFinalizer(ctx, r.Client, instance)
+	}
 
-// addFinalizer adds the finalizer to the Client if it doesn't already exist
-func (r *ClientReconciler) addFinalizer(instance *auth0v1alpha1.Client) error {
-	controllerutil.AddFinalizer(instance, finalizerName)
-	return r.Update(context.Background(), instance)
-}
+	// N.B output secret is deleted via owner reference garbage collection
 
-// removeFinalizer removes the finalizer from the Client if it exists
-func (r *ClientReconciler) removeFinalizer(instance *auth0v1alpha1.Client) error {
-	if !r.hasFinalizer(instance) {
-		return nil
-	}
+	if r.deletionPolicy(instance) == auth0v1alpha1.DeletionPolicyOrphan {
+		if err := r.removeOwnerTag(ctx, instance); err != nil {
+			return err
+		}
 
-	controllerutil.RemoveFinalizer(instance, finalizerName)
-	return r.Update(context.Background(), instance)
-}
+		r.Recorder.Event(
+			instance,
+			"Normal",
+			EventReasonOrphaned,
+			fmt.Sprintf(
+				"Left client %s (ID: %s) in Auth0",
+				instance.Spec.Name,
+				instance.Status.Auth0Id,
+			),
+		)
 
-// handleFinalizer handles the finalizer logic for the Client
-func (r *ClientReconciler) handleFinalizer(ctx context.Context, instance *auth0v1alpha1.Client) error {
-	if !r.hasFinalizer(instance) {
-		return nil
+		return removeFinalizer(ctx, r.Client, instance)
 	}
 
-	if instance.Auth0Id() == "" {
-		return r.removeFinalizer(instance)
+	api, err := deletionApi(ctx, r.Tenants, r.Recorder, instance, instance.Spec.TenantRef)
+	if err != nil {
+		return err
 	}
 
-	// N.B output secret is deleted via owner reference garbage collection
+	if api == nil {
+		return removeFinalizer(ctx, r.Client, instance)
+	}
 
-	err := r.Auth0Api.Client.Delete(ctx, instance.Status.Auth0Id)
+	err = api.Client.Delete(ctx, instance.Status.Auth0Id)
+
+	// The client has already been deleted in Auth0, so there's nothing left
+	// to clean up
+	if isNotFound(err) {
+		r.Recorder.Event(
+			instance,
+			"Normal",
+			EventReasonDeleted,
+			fmt.Sprintf(
+				"Client %s (ID: %s) was already deleted in Auth0",
+				instance.Spec.Name,
+				instance.Status.Auth0Id,
+			),
+		)
+
+		return removeFinalizer(ctx, r.Client, instance)
+	}
 
-	// TODO - better handling here if the client doesn't exist?
 	if err != nil {
 		r.Recorder.Event(instance, "Warning", EventReasonDeleteFailed, err.Error())
 		return err
@@ -64,5 +86,62 @@ func (r *ClientReconciler) handleFinalizer(ctx context.Context, instance *auth0v
 		),
 	)
 
-	return r.removeFinalizer(instance)
+	return removeFinalizer(ctx, r.Client, instance)
+}
+
+// removeOwnerTag removes the metadata key recording the Client as the owner
+// of the Auth0 client, so the client can be adopted again once it's orphaned
+func (r *ClientReconciler) removeOwnerTag(
+	ctx context.Context,
+	instance *auth0v1alpha1.Client,
+) error {
+	api, err := deletionApi(ctx, r.Tenants, r.Recorder, instance, instance.Spec.TenantRef)
+	if err != nil || api == nil {
+		return err
+	}
+
+	c, err := api.Client.Read(ctx, instance.Status.Auth0Id)
+
+	// There's no tag left to remove
+	if isNotFound(err) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if c.GetClientMetadata()[auth0v1alpha1.OwnerMetadataKey] != string(instance.UID) {
+		return nil
+	}
+
+	err = api.Client.Update(ctx, instance.Status.Auth0Id, &management.Client{
+		ClientMetadata: &map[string]interface{}{auth0v1alpha1.OwnerMetadataKey: nil},
+	})
+
+	if isNotFound(err) {
+		return nil
+	}
+
+	return err
+}
+
+// deletionPolicy returns the deletion policy of the Client. Adopted clients
+// existed before the Client, so they're orphaned unless the Client says
+// otherwise. Other clients fall back to the default deletion policy of the
+// reconciler
+func (r *ClientReconciler) deletionPolicy(instance *auth0v1alpha1.Client) string {
+	if instance.Spec.DeletionPolicy != "" {
+		return instance.Spec.DeletionPolicy
+	}
+
+	if instance.AdoptAuth0Id() != "" {
+		return auth0v1alpha1.DeletionPolicyOrphan
+	}
+
+	if r.DefaultDeletionPolicy != "" {
+		return r.DefaultDeletionPolicy
+	}
+
+	return auth0v1alpha1.DeletionPolicyDelete
 }
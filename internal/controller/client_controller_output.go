@@ -0,0 +1,348 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	auth0v1alpha1 "github.com/rgracey/auth0-operator/api/v1alpha1"
+)
+
+// clientOutput holds the details of a client that are written to its output
+// secret and config map, and that their templates are rendered with
+type clientOutput struct {
+	ClientId          string
+	ClientSecret      string
+	Domain            string
+	Issuer            string
+	TokenEndpoint     string
+	AuthorizeEndpoint string
+	JwksUri           string
+	Audience          string
+}
+
+// newClientOutput returns the details of a client in the tenant with the
+// domain, deriving its endpoints from the domain
+func newClientOutput(domain string, clientId string, clientSecret string, audience string) clientOutput {
+	issuer := "https://" + domain + "/"
+
+	return clientOutput{
+		ClientId:          clientId,
+		ClientSecret:      clientSecret,
+		Domain:            domain,
+		Issuer:            issuer,
+		TokenEndpoint:     issuer + "oauth/token",
+		AuthorizeEndpoint: issuer + "authorize",
+		JwksUri:           issuer + ".well-known/jwks.json",
+		Audience:          audience,
+	}
+}
+
+// secretData returns the output secret data of the client, with the client
+// secret under the key of the output secret ref, other details under the
+// keys they are configured with and the rendered templates
+func (o clientOutput) secretData(clientSecret auth0v1alpha1.ClientSecret) (map[string]string, error) {
+	data := map[string]string{
+		clientSecret.OutputSecretRef.Key: o.ClientSecret,
+	}
+
+	if keys := clientSecret.OutputKeys; keys != nil {
+		for key, value := range map[string]string{
+			keys.ClientId:          o.ClientId,
+			keys.Domain:            o.Domain,
+			keys.Issuer:            o.Issuer,
+			keys.TokenEndpoint:     o.TokenEndpoint,
+			keys.AuthorizeEndpoint: o.AuthorizeEndpoint,
+			keys.JwksUri:           o.JwksUri,
+			keys.Audience:          o.Audience,
+		} {
+			if key != "" {
+				data[key] = value
+			}
+		}
+	}
+
+	rendered, err := o.render(clientSecret.OutputTemplates)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for key, value := range rendered {
+		data[key] = value
+	}
+
+	return data, nil
+}
+
+// configMapData returns the output config map data of the client, which
+// never includes the client secret
+func (o clientOutput) configMapData(configMap auth0v1alpha1.OutputConfigMap) (map[string]string, error) {
+	o.ClientSecret = ""
+	return o.render(configMap.Templates)
+}
+
+// render renders each template with the details of the client
+func (o clientOutput) render(templates map[string]string) (map[string]string, error) {
+	rendered := make(map[string]string, len(templates))
+
+	for key, text := range templates {
+		tmpl, err := template.New(key).Parse(text)
+
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse template %s: %w", key, err)
+		}
+
+		var b strings.Builder
+		if err := tmpl.Execute(&b, o); err != nil {
+			return nil, fmt.Errorf("unable to render template %s: %w", key, err)
+		}
+
+		rendered[key] = b.String()
+	}
+
+	return rendered, nil
+}
+
+// reconcileOutputs writes the output secret and config map of the client, if
+// requested, and records the outcome in their conditions
+func (r *ClientReconciler) reconcileOutputs(
+	ctx context.Context,
+	instance *auth0v1alpha1.Client,
+	clientSecret string,
+) error {
+	if !instance.ShouldOutputSecret() && !instance.ShouldOutputConfigMap() {
+		return nil
+	}
+
+	domain, err := r.Tenants.Domain(ctx, instance.Namespace, instance.Spec.TenantRef)
+
+	if err != nil {
+		if instance.ShouldOutputSecret() {
+			setSecretOutputReadyCondition(instance, metav1.ConditionFalse, ConditionReasonSecretOutputFailed, err.Error())
+		}
+		if instance.ShouldOutputConfigMap() {
+			setConfigMapOutputReadyCondition(instance, metav1.ConditionFalse, ConditionReasonConfigMapOutputFailed, err.Error())
+		}
+		return err
+	}
+
+	output := newClientOutput(domain, instance.Status.Auth0Id, clientSecret, instance.Spec.ClientSecret.OutputAudience)
+
+	if instance.ShouldOutputSecret() {
+		if err := r.upsertOutputSecret(ctx, instance, output); err != nil {
+			setSecretOutputReadyCondition(instance, metav1.ConditionFalse, ConditionReasonSecretOutputFailed, err.Error())
+			return err
+		}
+
+		setSecretOutputReadyCondition(
+			instance,
+			metav1.ConditionTrue,
+			ConditionReasonSecretOutputWritten,
+			fmt.Sprintf("Client secret written to secret %s", instance.Spec.ClientSecret.OutputSecretRef.Name),
+		)
+	}
+
+	if instance.ShouldOutputConfigMap() {
+		if err := r.upsertOutputConfigMap(ctx, instance, output); err != nil {
+			setConfigMapOutputReadyCondition(instance, metav1.ConditionFalse, ConditionReasonConfigMapOutputFailed, err.Error())
+			return err
+		}
+
+		setConfigMapOutputReadyCondition(
+			instance,
+			metav1.ConditionTrue,
+			ConditionReasonConfigMapOutputWritten,
+			fmt.Sprintf("Client details written to config map %s", instance.Spec.OutputConfigMap.Name),
+		)
+	}
+
+	return nil
+}
+
+// upsertOutputSecret creates or updates the output secret for a client
+func (r *ClientReconciler) upsertOutputSecret(
+	ctx context.Context,
+	instance *auth0v1alpha1.Client,
+	output clientOutput,
+) error {
+	secretRef := instance.Spec.ClientSecret.OutputSecretRef
+	data, err := output.secretData(instance.Spec.ClientSecret)
+
+	if err != nil {
+		return err
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: ctrl.ObjectMeta{
+			Namespace: instance.Namespace,
+			Name:      secretRef.Name,
+		},
+	}
+
+	err = r.Get(
+		ctx,
+		client.ObjectKey{
+			Namespace: instance.Namespace,
+			Name:      secretRef.Name,
+		},
+		secret,
+	)
+
+	if err == nil {
+		// The secret replaced by a rotation is kept until the overlap ends,
+		// carried over from the secret after the first write
+		previousKey := instance.PreviousSecretKey()
+		if inRotationOverlap(instance, time.Now()) {
+			if previous := string(secret.Data[secretRef.Key]); previous != "" && previous != output.ClientSecret {
+				data[previousKey] = previous
+			} else if previous, ok := secret.Data[previousKey]; ok {
+				data[previousKey] = string(previous)
+			}
+		}
+
+		// Keys written by others are kept, while keys the Client wrote that
+		// are no longer configured are removed
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+
+		for _, key := range staleOutputKeys(instance, secret, data) {
+			delete(secret.Data, key)
+		}
+
+		for key, value := range secretBytes(data) {
+			secret.Data[key] = value
+		}
+
+		recordOutputKeys(secret, data)
+		return r.Update(ctx, secret)
+	}
+
+	if client.IgnoreNotFound(err) == nil {
+		secret.Data = secretBytes(data)
+		recordOutputKeys(secret, data)
+		if err = ctrl.SetControllerReference(instance, secret, r.Scheme); err != nil {
+			return err
+		}
+
+		return r.Create(ctx, secret)
+	}
+
+	return err
+}
+
+// staleOutputKeys returns the keys of an output that the Client wrote
+// before but no longer writes. Outputs the Client doesn't control are only
+// ever merged into, so nothing is removed from them
+func staleOutputKeys(instance *auth0v1alpha1.Client, output client.Object, data map[string]string) []string {
+	if !metav1.IsControlledBy(output, instance) {
+		return nil
+	}
+
+	var stale []string
+	for _, key := range strings.Split(output.GetAnnotations()[auth0v1alpha1.OutputKeysAnnotation], ",") {
+		if _, ok := data[key]; key != "" && !ok {
+			stale = append(stale, key)
+		}
+	}
+
+	return stale
+}
+
+// recordOutputKeys records the keys the Client writes to an output in its
+// annotations
+func recordOutputKeys(output client.Object, data map[string]string) {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	annotations := output.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+
+	annotations[auth0v1alpha1.OutputKeysAnnotation] = strings.Join(keys, ",")
+	output.SetAnnotations(annotations)
+}
+
+// secretBytes converts string data to secret data
+func secretBytes(data map[string]string) map[string][]byte {
+	converted := make(map[string][]byte, len(data))
+	for key, value := range data {
+		converted[key] = []byte(value)
+	}
+
+	return converted
+}
+
+// upsertOutputConfigMap creates or updates the output config map for a
+// client
+func (r *ClientReconciler) upsertOutputConfigMap(
+	ctx context.Context,
+	instance *auth0v1alpha1.Client,
+	output clientOutput,
+) error {
+	name := instance.Spec.OutputConfigMap.Name
+	data, err := output.configMapData(*instance.Spec.OutputConfigMap)
+
+	if err != nil {
+		return err
+	}
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: ctrl.ObjectMeta{
+			Namespace: instance.Namespace,
+			Name:      name,
+		},
+	}
+
+	err = r.Get(
+		ctx,
+		client.ObjectKey{
+			Namespace: instance.Namespace,
+			Name:      name,
+		},
+		configMap,
+	)
+
+	if err == nil {
+		if configMap.Data == nil {
+			configMap.Data = map[string]string{}
+		}
+
+		for _, key := range staleOutputKeys(instance, configMap, data) {
+			delete(configMap.Data, key)
+		}
+
+		for key, value := range data {
+			configMap.Data[key] = value
+		}
+
+		recordOutputKeys(configMap, data)
+		return r.Update(ctx, configMap)
+	}
+
+	if client.IgnoreNotFound(err) == nil {
+		configMap.Data = data
+		recordOutputKeys(configMap, data)
+		if err = ctrl.SetControllerReference(instance, configMap, r.Scheme); err != nil {
+			return err
+		}
+
+		return r.Create(ctx, configMap)
+	}
+
+	return err
+}
@@ -0,0 +1,193 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/auth0/go-auth0/management"
+	auth0v1alpha1 "github.com/rgracey/auth0-operator/api/v1alpha1"
+)
+
+// rotationDue returns true if the client secret should be rotated, either
+// because a rotation was requested or because its rotation schedule is due
+func rotationDue(instance *auth0v1alpha1.Client, now time.Time) bool {
+	if instance.RotationRequested() {
+		return true
+	}
+
+	next, ok := nextRotation(instance)
+	return ok && !now.Before(next)
+}
+
+// nextRotation returns when the client secret is next rotated on schedule.
+// Clients that have never been rotated are scheduled from their creation
+func nextRotation(instance *auth0v1alpha1.Client) (time.Time, bool) {
+	schedule := instance.Spec.ClientSecret.RotationSchedule
+	if schedule == nil || schedule.Duration <= 0 || !instance.CanRotateSecret() {
+		return time.Time{}, false
+	}
+
+	last := instance.CreationTimestamp.Time
+	if instance.Status.LastRotated != nil {
+		last = instance.Status.LastRotated.Time
+	}
+
+	return last.Add(schedule.Duration), true
+}
+
+// inRotationOverlap returns true if the client secret was rotated recently
+// enough that the previous secret should still be output
+func inRotationOverlap(instance *auth0v1alpha1.Client, now time.Time) bool {
+	if instance.Status.LastRotated == nil {
+		return false
+	}
+
+	return now.Before(instance.Status.LastRotated.Add(instance.RotationOverlap()))
+}
+
+// rotationRequeueAfter returns how long until the client secret is next
+// rotated or the rotation overlap ends, or 0 if neither is pending
+func rotationRequeueAfter(instance *auth0v1alpha1.Client, now time.Time) time.Duration {
+	var after time.Duration
+
+	earlier := func(t time.Time) {
+		if d := t.Sub(now); d > 0 && (after == 0 || d < after) {
+			after = d
+		}
+	}
+
+	if next, ok := nextRotation(instance); ok {
+		earlier(next)
+	}
+
+	if inRotationOverlap(instance, now) {
+		earlier(instance.Status.LastRotated.Add(instance.RotationOverlap()))
+	}
+
+	return after
+}
+
+// rotateSecret rotates the secret of the Auth0 client and returns the new
+// secret, recording the rotation in the status of the Client
+func (r *ClientReconciler) rotateSecret(
+	ctx context.Context,
+	api *management.Management,
+	instance *auth0v1alpha1.Client,
+) (string, error) {
+	logger := log.FromContext(ctx)
+
+	undo, err := r.recordRotation(ctx, instance)
+
+	if err != nil {
+		logger.Error(err, "unable to record client secret rotation", "name", instance.Spec.Name)
+		return "", err
+	}
+
+	logger.Info("rotating client secret", "name", instance.Spec.Name)
+	rotated, err := api.Client.RotateSecret(ctx, instance.Auth0Id())
+
+	if err != nil {
+		undo()
+		logger.Error(err, "unable to rotate client secret", "name", instance.Spec.Name)
+		r.Recorder.Event(instance, "Warning", EventReasonRotateFailed, err.Error())
+		setSyncedCondition(instance, metav1.ConditionFalse, EventReasonRotateFailed, err.Error())
+		return "", err
+	}
+
+	r.recordRotatedEvent(instance)
+
+	return rotated.GetClientSecret(), nil
+}
+
+// rotateGeneratedSecret replaces the generated secret of the Client with a
+// new one, which is pushed to Auth0 like any other change to the secret
+func (r *ClientReconciler) rotateGeneratedSecret(
+	ctx context.Context,
+	instance *auth0v1alpha1.Client,
+) (*string, error) {
+	logger := log.FromContext(ctx)
+
+	undo, err := r.recordRotation(ctx, instance)
+
+	if err != nil {
+		logger.Error(err, "unable to record client secret rotation", "name", instance.Spec.Name)
+		return nil, err
+	}
+
+	logger.Info("rotating generated client secret", "name", instance.Spec.Name)
+	value, err := r.storeGeneratedSecret(ctx, instance)
+
+	if err != nil {
+		undo()
+		logger.Error(err, "unable to rotate generated client secret", "name", instance.Spec.Name)
+		r.Recorder.Event(instance, "Warning", EventReasonRotateFailed, err.Error())
+		setSyncedCondition(instance, metav1.ConditionFalse, EventReasonRotateFailed, err.Error())
+		return nil, err
+	}
+
+	r.recordRotatedEvent(instance)
+
+	return &value, nil
+}
+
+// recordRotation records a rotation of the client secret in the status of the
+// Client before it happens. If the status couldn't be updated afterwards the
+// secret would be rotated again, losing the previous secret while it's still
+// in use. The returned func undoes the record if the rotation fails
+func (r *ClientReconciler) recordRotation(
+	ctx context.Context,
+	instance *auth0v1alpha1.Client,
+) (func(), error) {
+	lastRotated := instance.Status.LastRotated
+	lastRotationRequest := instance.Status.LastRotationRequest
+
+	undo := func() {
+		instance.Status.LastRotated = lastRotated
+		instance.Status.LastRotationRequest = lastRotationRequest
+	}
+
+	now := metav1.Now()
+	instance.Status.LastRotated = &now
+	instance.Status.LastRotationRequest = instance.GetAnnotations()[auth0v1alpha1.RotateSecretAnnotation]
+
+	if err := r.Status().Update(ctx, instance); err != nil {
+		undo()
+		return nil, err
+	}
+
+	return undo, nil
+}
+
+// recordRotatedEvent records an event for a rotation of the client secret
+func (r *ClientReconciler) recordRotatedEvent(instance *auth0v1alpha1.Client) {
+	r.Recorder.Event(
+		instance,
+		"Normal",
+		EventReasonRotated,
+		fmt.Sprintf(
+			"Rotated secret of client %s (ID: %s)",
+			instance.Spec.Name,
+			instance.Status.Auth0Id,
+		),
+	)
+}
+
+// rejectRotation acknowledges a rotation requested for a client secret that
+// is set by literal or secretRef, which the operator can't rotate
+func (r *ClientReconciler) rejectRotation(instance *auth0v1alpha1.Client) {
+	instance.Status.LastRotationRequest = instance.GetAnnotations()[auth0v1alpha1.RotateSecretAnnotation]
+
+	r.Recorder.Event(
+		instance,
+		"Warning",
+		EventReasonRotationUnsupported,
+		fmt.Sprintf(
+			"Secret of client %s can't be rotated as it's set by literal or secretRef",
+			instance.Spec.Name,
+		),
+	)
+}
@@ -0,0 +1,125 @@
+package controller
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	auth0v1alpha1 "github.com/rgracey/auth0-operator/api/v1alpha1"
+)
+
+// generatedSecretBytes is the number of random bytes in a generated client
+// secret, which are encoded to 64 characters
+const generatedSecretBytes = 48
+
+// generateSecret returns a random client secret
+func generateSecret() (string, error) {
+	b := make([]byte, generatedSecretBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// loadGeneratedSecret returns the client secret held in the generated secret
+// of the Client, generating and storing one if there isn't one yet
+func (r *ClientReconciler) loadGeneratedSecret(
+	ctx context.Context,
+	instance *auth0v1alpha1.Client,
+) (string, error) {
+	ref := instance.Spec.ClientSecret.GeneratedSecretRef
+	key := client.ObjectKey{Namespace: instance.Namespace, Name: ref.Name}
+
+	secret := &corev1.Secret{}
+	err := r.Get(ctx, key, secret)
+
+	if client.IgnoreNotFound(err) != nil {
+		return "", err
+	}
+
+	// A secret written by a previous reconcile, e.g. by a rotation, may not
+	// be in the cache yet. Pushing the cached secret to Auth0 would undo it,
+	// so the secret is read from the API server until the cache catches up
+	if written, ok := r.generatedSecretVersions.Load(instance.UID); ok {
+		if err == nil && secret.ResourceVersion == written {
+			r.generatedSecretVersions.Delete(instance.UID)
+		} else {
+			secret = &corev1.Secret{}
+			err = r.APIReader.Get(ctx, key, secret)
+
+			if client.IgnoreNotFound(err) != nil {
+				return "", err
+			}
+
+			// The secret may have changed since, in which case the cache has
+			// to catch up with that change instead
+			if err == nil {
+				r.generatedSecretVersions.Store(instance.UID, secret.ResourceVersion)
+			} else {
+				r.generatedSecretVersions.Delete(instance.UID)
+			}
+		}
+	}
+
+	if value := secret.Data[ref.Key]; err == nil && len(value) > 0 {
+		return string(value), nil
+	}
+
+	return r.storeGeneratedSecret(ctx, instance)
+}
+
+// storeGeneratedSecret generates a new client secret and writes it to the
+// generated secret of the Client, creating the secret if it doesn't exist
+func (r *ClientReconciler) storeGeneratedSecret(
+	ctx context.Context,
+	instance *auth0v1alpha1.Client,
+) (string, error) {
+	ref := instance.Spec.ClientSecret.GeneratedSecretRef
+	value, err := generateSecret()
+
+	if err != nil {
+		return "", err
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: ctrl.ObjectMeta{
+			Namespace: instance.Namespace,
+			Name:      ref.Name,
+		},
+	}
+
+	err = r.Get(ctx, client.ObjectKeyFromObject(secret), secret)
+
+	if err == nil {
+		secret.StringData = map[string]string{ref.Key: value}
+		if err := r.Update(ctx, secret); err != nil {
+			return "", err
+		}
+
+		r.generatedSecretVersions.Store(instance.UID, secret.ResourceVersion)
+		return value, nil
+	}
+
+	if client.IgnoreNotFound(err) != nil {
+		return "", err
+	}
+
+	// If the secret was created by a reconcile that isn't in the cache yet,
+	// creating it fails rather than replacing the secret
+	secret.StringData = map[string]string{ref.Key: value}
+	if err = ctrl.SetControllerReference(instance, secret, r.Scheme); err != nil {
+		return "", err
+	}
+
+	if err = r.Create(ctx, secret); err != nil {
+		return "", err
+	}
+
+	r.generatedSecretVersions.Store(instance.UID, secret.ResourceVersion)
+	return value, nil
+}
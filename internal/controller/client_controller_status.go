@@ -0,0 +1,107 @@
+package controller
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	auth0v1alpha1 "github.com/rgracey/auth0-operator/api/v1alpha1"
+)
+
+const (
+	ConditionReasonReady               = "Ready"
+	ConditionReasonNotSynced           = "NotSynced"
+	ConditionReasonSecretOutputPending = "SecretOutputPending"
+	ConditionReasonSecretLoadFailed    = "SecretLoadFailed"
+	ConditionReasonSecretOutputFailed  = "SecretOutputFailed"
+	ConditionReasonSecretOutputWritten = "SecretOutputWritten"
+	ConditionReasonNotFound            = "NotFound"
+	ConditionReasonTenantUnavailable   = "TenantUnavailable"
+	ConditionReasonClientPending       = "ClientPending"
+
+	ConditionReasonConfigMapOutputPending = "ConfigMapOutputPending"
+	ConditionReasonConfigMapOutputFailed  = "ConfigMapOutputFailed"
+	ConditionReasonConfigMapOutputWritten = "ConfigMapOutputWritten"
+)
+
+// setSecretOutputReadyCondition sets the SecretOutputReady condition of the Client
+func setSecretOutputReadyCondition(
+	instance *auth0v1alpha1.Client,
+	status metav1.ConditionStatus,
+	reason string,
+	message string,
+) {
+	setCondition(instance, auth0v1alpha1.ConditionTypeSecretOutputReady, status, reason, message)
+}
+
+// setConfigMapOutputReadyCondition sets the ConfigMapOutputReady condition of
+// the Client
+func setConfigMapOutputReadyCondition(
+	instance *auth0v1alpha1.Client,
+	status metav1.ConditionStatus,
+	reason string,
+	message string,
+) {
+	setCondition(instance, auth0v1alpha1.ConditionTypeConfigMapOutputReady, status, reason, message)
+}
+
+// updateStatus records the outcome of a reconcile in the status of the
+// Client, deriving the Ready condition from the other conditions
+func (r *ClientReconciler) updateStatus(
+	ctx context.Context,
+	instance *auth0v1alpha1.Client,
+	syncErr error,
+) error {
+	if syncErr != nil {
+		instance.Status.LastError = syncErr.Error()
+	} else {
+		now := metav1.Now()
+		instance.Status.LastError = ""
+		instance.Status.LastSyncTime = &now
+		instance.Status.ObservedGeneration = instance.Generation
+	}
+
+	conditions := &instance.Status.Conditions
+
+	if !instance.ShouldOutputSecret() {
+		meta.RemoveStatusCondition(conditions, auth0v1alpha1.ConditionTypeSecretOutputReady)
+	}
+
+	if !instance.ShouldOutputConfigMap() {
+		meta.RemoveStatusCondition(conditions, auth0v1alpha1.ConditionTypeConfigMapOutputReady)
+	}
+
+	switch {
+	case !meta.IsStatusConditionTrue(*conditions, auth0v1alpha1.ConditionTypeSynced):
+		message := "Client hasn't been synced with Auth0"
+		if synced := meta.FindStatusCondition(*conditions, auth0v1alpha1.ConditionTypeSynced); synced != nil {
+			message = synced.Message
+		}
+
+		setCondition(instance, auth0v1alpha1.ConditionTypeReady, metav1.ConditionFalse, ConditionReasonNotSynced, message)
+
+	case instance.ShouldOutputSecret() &&
+		!meta.IsStatusConditionTrue(*conditions, auth0v1alpha1.ConditionTypeSecretOutputReady):
+		message := "Client secret hasn't been written to the output secret"
+		if output := meta.FindStatusCondition(*conditions, auth0v1alpha1.ConditionTypeSecretOutputReady); output != nil {
+			message = output.Message
+		}
+
+		setCondition(instance, auth0v1alpha1.ConditionTypeReady, metav1.ConditionFalse, ConditionReasonSecretOutputPending, message)
+
+	case instance.ShouldOutputConfigMap() &&
+		!meta.IsStatusConditionTrue(*conditions, auth0v1alpha1.ConditionTypeConfigMapOutputReady):
+		message := "Client details haven't been written to the output config map"
+		if output := meta.FindStatusCondition(*conditions, auth0v1alpha1.ConditionTypeConfigMapOutputReady); output != nil {
+			message = output.Message
+		}
+
+		setCondition(instance, auth0v1alpha1.ConditionTypeReady, metav1.ConditionFalse, ConditionReasonConfigMapOutputPending, message)
+
+	default:
+		setCondition(instance, auth0v1alpha1.ConditionTypeReady, metav1.ConditionTrue, ConditionReasonReady, "Client is ready")
+	}
+
+	return r.Status().Update(ctx, instance)
+}
@@ -4,11 +4,13 @@ import (
 	"context"
 	"time"
 
+	"github.com/auth0/go-auth0"
 	"github.com/auth0/go-auth0/management"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	auth0v1alpha1 "github.com/rgracey/auth0-operator/api/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -83,6 +85,155 @@ var _ = Describe("Client controller", func() {
 			// Expect(*c.ClientMetadata).To(ConsistOf(client.Spec.Metadata))
 		})
 
+		It("should report the client as ready in its status", func() {
+			Eventually(func() bool {
+				if err := k8sClient.Get(ctx, key, client); err != nil {
+					return false
+				}
+				return meta.IsStatusConditionTrue(client.Status.Conditions, auth0v1alpha1.ConditionTypeReady)
+			}).WithTimeout(timeout).Should(BeTrue())
+
+			Expect(meta.IsStatusConditionTrue(client.Status.Conditions, auth0v1alpha1.ConditionTypeSynced)).To(BeTrue())
+			Expect(client.Status.ObservedGeneration).To(Equal(client.Generation))
+			Expect(client.Status.LastSyncTime).ToNot(BeNil())
+			Expect(client.Status.LastError).To(BeEmpty())
+		})
+
+		It("should revert changes made outside of the operator", func() {
+			Eventually(func() bool {
+				if err := k8sClient.Get(ctx, key, client); err != nil {
+					return false
+				}
+				return client.Status.ObservedGeneration == client.Generation
+			}).WithTimeout(timeout).Should(BeTrue())
+
+			// Change the client as if it was edited in the dashboard
+			Expect(auth0Api.Client.Update(ctx, client.Status.Auth0Id, &management.Client{
+				Description: auth0.String("Changed in the dashboard"),
+			})).To(Succeed())
+
+			// Trigger a reconcile without changing the spec
+			client.Annotations = map[string]string{"test": time.Now().String()}
+			Expect(k8sClient.Update(ctx, client)).To(Succeed())
+
+			Eventually(func() []string {
+				if err := k8sClient.Get(ctx, key, client); err != nil {
+					return nil
+				}
+				return client.Status.DriftedFields
+			}).WithTimeout(timeout).Should(ConsistOf("description"))
+
+			c, err := auth0Api.Client.Read(ctx, client.Status.Auth0Id)
+			Expect(err).To(BeNil())
+			Expect(c.GetDescription()).To(Equal(client.Spec.Description))
+		})
+
+		It("should tag an untagged client without reporting drift", func() {
+			Eventually(func() (bool, error) {
+				err := k8sClient.Get(ctx, key, client)
+				return client.Status.ObservedGeneration == client.Generation, err
+			}).WithTimeout(timeout).Should(BeTrue())
+
+			// Untag the client as if it was created before clients were
+			// tagged, and make a change that is drift
+			Expect(auth0Api.Client.Update(ctx, client.Status.Auth0Id, &management.Client{
+				Description:    auth0.String("Changed in the dashboard"),
+				ClientMetadata: &map[string]interface{}{auth0v1alpha1.OwnerMetadataKey: nil},
+			})).To(Succeed())
+
+			// Trigger a reconcile without changing the spec
+			client.Annotations = map[string]string{"test": time.Now().String()}
+			Expect(k8sClient.Update(ctx, client)).To(Succeed())
+
+			Eventually(func() ([]string, error) {
+				err := k8sClient.Get(ctx, key, client)
+				return client.Status.DriftedFields, err
+			}).WithTimeout(timeout).Should(ConsistOf("description"))
+
+			c, err := auth0Api.Client.Read(ctx, client.Status.Auth0Id)
+			Expect(err).To(BeNil())
+			Expect(c.GetClientMetadata()).To(HaveKeyWithValue(auth0v1alpha1.OwnerMetadataKey, string(client.UID)))
+		})
+
+		It("should reject the owner metadata key", func() {
+			client.Spec.Metadata = map[string]string{auth0v1alpha1.OwnerMetadataKey: "another-uid"}
+			Expect(k8sClient.Update(ctx, client)).ToNot(Succeed())
+		})
+
+		It("should tag the client with the UID of the Client", func() {
+			Expect(auth0Client.GetClientMetadata()).To(HaveKeyWithValue(auth0v1alpha1.OwnerMetadataKey, string(client.UID)))
+		})
+
+		It("should clear the pending create once the ID is recorded", func() {
+			Expect(client.Status.PendingCreate).To(BeFalse())
+		})
+
+		It("should reuse the client it created if its ID is lost", func() {
+			createdId := client.Status.Auth0Id
+
+			// Lose the ID as if the status update after creating it failed
+			client.Status.Auth0Id = ""
+			client.Status.PendingCreate = true
+			Expect(k8sClient.Status().Update(ctx, client)).To(Succeed())
+
+			// Trigger a reconcile without changing the spec
+			client.Annotations = map[string]string{"test": time.Now().String()}
+			Expect(k8sClient.Update(ctx, client)).To(Succeed())
+
+			Eventually(func() string {
+				if err := k8sClient.Get(ctx, key, client); err != nil {
+					return ""
+				}
+				return client.Status.Auth0Id
+			}).WithTimeout(timeout).Should(Equal(createdId))
+		})
+
+		It("should resync when the credentials of its tenant change", func() {
+			Eventually(func() bool {
+				if err := k8sClient.Get(ctx, key, client); err != nil {
+					return false
+				}
+				return client.Status.LastSyncTime != nil
+			}).WithTimeout(timeout).Should(BeTrue())
+
+			lastSyncTime := client.Status.LastSyncTime
+
+			// The sync time has a precision of a second
+			time.Sleep(time.Second)
+
+			secret := &corev1.Secret{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: "default", Name: "test-suite-tenant"}, secret)).To(Succeed())
+			secret.StringData = map[string]string{"rotated-at": time.Now().String()}
+			Expect(k8sClient.Update(ctx, secret)).To(Succeed())
+
+			Eventually(func() bool {
+				if err := k8sClient.Get(ctx, key, client); err != nil {
+					return false
+				}
+				return client.Status.LastSyncTime.After(lastSyncTime.Time)
+			}).WithTimeout(timeout).Should(BeTrue())
+		})
+
+		When("a sync interval is specified", func() {
+			BeforeEach(func() {
+				client.Spec.SyncInterval = &metav1.Duration{Duration: time.Second}
+			})
+
+			It("should revert changes made outside of the operator without the Client changing", func() {
+				Expect(auth0Api.Client.Update(ctx, client.Status.Auth0Id, &management.Client{
+					Description: auth0.String("Changed in the dashboard"),
+				})).To(Succeed())
+
+				Eventually(func() string {
+					c, err := auth0Api.Client.Read(ctx, client.Status.Auth0Id)
+					if err != nil {
+						return ""
+					}
+					return c.GetDescription()
+				}).WithTimeout(timeout).WithPolling(1 * time.Second).Should(Equal(client.Spec.Description))
+			})
+		})
+
 		When("a secret is provided", func() {
 			const expectedSecret = "ThisIsA48CharacterSecretSoItIsLongEnoughForAuth0"
 
@@ -96,6 +247,31 @@ var _ = Describe("Client controller", func() {
 				It("should create a client in Auth0 with the provided secret", func() {
 					Expect(*auth0Client.ClientSecret).To(Equal(expectedSecret))
 				})
+
+				It("should acknowledge but not perform requested rotations", func() {
+					Expect(k8sClient.Get(ctx, key, client)).To(Succeed())
+					client.Annotations = map[string]string{
+						auth0v1alpha1.RotateSecretAnnotation: "1",
+					}
+					Expect(k8sClient.Update(ctx, client)).To(Succeed())
+
+					Eventually(func() (string, error) {
+						err := k8sClient.Get(ctx, key, client)
+						return client.Status.LastRotationRequest, err
+					}).WithTimeout(timeout).Should(Equal("1"))
+
+					Expect(client.Status.LastRotated).To(BeNil())
+
+					current, err := auth0Api.Client.Read(ctx, client.Status.Auth0Id)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(current.GetClientSecret()).To(Equal(expectedSecret))
+				})
+
+				It("should reject a rotation schedule", func() {
+					Expect(k8sClient.Get(ctx, key, client)).To(Succeed())
+					client.Spec.ClientSecret.RotationSchedule = &metav1.Duration{Duration: time.Hour}
+					Expect(k8sClient.Update(ctx, client)).ToNot(Succeed())
+				})
 			})
 
 			Describe("as a secret reference", func() {
@@ -132,6 +308,21 @@ var _ = Describe("Client controller", func() {
 					It("should create a client in Auth0 with the provided secret", func() {
 						Expect(*auth0Client.ClientSecret).To(Equal(expectedSecret))
 					})
+
+					It("should update the client in Auth0 when the secret changes", func() {
+						const updatedSecret = "ThisIsAnUpdated48CharacterSecretThatAuth0Accepts"
+
+						secret.StringData = map[string]string{"test-key": updatedSecret}
+						Expect(k8sClient.Update(ctx, secret)).To(Succeed())
+
+						Eventually(func() string {
+							c, err := auth0Api.Client.Read(ctx, client.Status.Auth0Id)
+							if err != nil {
+								return ""
+							}
+							return c.GetClientSecret()
+						}).WithTimeout(timeout).WithPolling(1 * time.Second).Should(Equal(updatedSecret))
+					})
 				})
 
 				When("the key doesn't exist in the secret", func() {
@@ -169,6 +360,63 @@ var _ = Describe("Client controller", func() {
 			})
 		})
 
+		When("a generated secret is requested", func() {
+			var generatedSecretName string
+			const generatedSecretKey = "client-secret"
+
+			BeforeEach(func() {
+				generatedSecretName = "test-generated-secret-" + time.Now().Format("20060102150405")
+				client.Spec.ClientSecret = auth0v1alpha1.ClientSecret{
+					GeneratedSecretRef: &auth0v1alpha1.SecretRef{
+						Name: generatedSecretName,
+						Key:  generatedSecretKey,
+					},
+				}
+			})
+
+			It("should store the generated secret and use it in Auth0", func() {
+				secret := &corev1.Secret{}
+				Expect(k8sClient.Get(
+					ctx,
+					types.NamespacedName{Namespace: key.Namespace, Name: generatedSecretName},
+					secret,
+				)).To(Succeed())
+
+				Expect(secret.OwnerReferences).ToNot(BeEmpty())
+				Expect(secret.Data[generatedSecretKey]).To(HaveLen(64))
+				Expect(auth0Client.GetClientSecret()).To(Equal(string(secret.Data[generatedSecretKey])))
+			})
+
+			When("the generated secret already exists", func() {
+				const restoredSecret = "restored-secretrestored-secretrestored-secretrestored"
+
+				BeforeEach(func() {
+					Expect(k8sClient.Create(ctx, &corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      generatedSecretName,
+							Namespace: key.Namespace,
+						},
+						StringData: map[string]string{
+							generatedSecretKey: restoredSecret,
+						},
+					})).To(Succeed())
+				})
+
+				AfterEach(func() {
+					Expect(k8sClient.Delete(context.Background(), &corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      generatedSecretName,
+							Namespace: key.Namespace,
+						},
+					})).To(Succeed())
+				})
+
+				It("should use the existing secret in Auth0", func() {
+					Expect(auth0Client.GetClientSecret()).To(Equal(restoredSecret))
+				})
+			})
+		})
+
 		When("an output secret is specified", func() {
 			var outputSecretName string
 			const outputSecretKey = "test-key"
@@ -213,6 +461,14 @@ var _ = Describe("Client controller", func() {
 					return string(s.Data[outputSecretKey]) != ""
 				}).WithTimeout(timeout).Should(BeTrue())
 
+				By("checking that the SecretOutputReady condition is set")
+				Eventually(func() bool {
+					if err := k8sClient.Get(ctx, key, client); err != nil {
+						return false
+					}
+					return meta.IsStatusConditionTrue(client.Status.Conditions, auth0v1alpha1.ConditionTypeSecretOutputReady)
+				}).WithTimeout(timeout).Should(BeTrue())
+
 				By("checking that the secret has an owner reference")
 				Eventually(func() bool {
 					secret := &corev1.Secret{}
@@ -242,6 +498,7 @@ var _ = Describe("Client controller", func() {
 						},
 						StringData: map[string]string{
 							outputSecretKey: "old-value",
+							"other-key":     "other-value",
 						},
 					}
 
@@ -267,7 +524,327 @@ var _ = Describe("Client controller", func() {
 						return string(secret.Data[outputSecretKey]) != "old-value"
 					}).WithTimeout(timeout).Should(BeTrue())
 				})
+
+				It("should keep the keys it didn't write", func() {
+					secret := &corev1.Secret{}
+					secretKey := types.NamespacedName{Namespace: key.Namespace, Name: outputSecretName}
+
+					Eventually(func() (map[string]string, error) {
+						err := k8sClient.Get(ctx, secretKey, secret)
+						return secret.Annotations, err
+					}).WithTimeout(timeout).Should(HaveKeyWithValue(auth0v1alpha1.OutputKeysAnnotation, outputSecretKey))
+
+					Expect(string(secret.Data["other-key"])).To(Equal("other-value"))
+				})
+			})
+
+			It("should rotate the secret when requested and keep the previous one", func() {
+				secret := &corev1.Secret{}
+				secretKey := types.NamespacedName{Namespace: key.Namespace, Name: outputSecretName}
+
+				var previous string
+				Eventually(func() string {
+					if err := k8sClient.Get(ctx, secretKey, secret); err != nil {
+						return ""
+					}
+					previous = string(secret.Data[outputSecretKey])
+					return previous
+				}).WithTimeout(timeout).ShouldNot(BeEmpty())
+
+				By("requesting a rotation")
+				Expect(k8sClient.Get(ctx, key, client)).To(Succeed())
+				client.Annotations = map[string]string{
+					auth0v1alpha1.RotateSecretAnnotation: "1",
+				}
+				Expect(k8sClient.Update(ctx, client)).To(Succeed())
+
+				Eventually(func() bool {
+					if err := k8sClient.Get(ctx, key, client); err != nil {
+						return false
+					}
+					return client.Status.LastRotated != nil && client.Status.LastRotationRequest == "1"
+				}).WithTimeout(timeout).Should(BeTrue())
+
+				Eventually(func() bool {
+					if err := k8sClient.Get(ctx, secretKey, secret); err != nil {
+						return false
+					}
+					return string(secret.Data[outputSecretKey]) != previous
+				}).WithTimeout(timeout).Should(BeTrue())
+
+				Expect(string(secret.Data[outputSecretKey+"-previous"])).To(Equal(previous))
+
+				By("checking the secret in Auth0 was rotated")
+				rotated, err := auth0Api.Client.Read(ctx, client.Status.Auth0Id)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(rotated.GetClientSecret()).To(Equal(string(secret.Data[outputSecretKey])))
+			})
+
+			When("output keys are specified", func() {
+				BeforeEach(func() {
+					client.Spec.ClientSecret.OutputKeys = &auth0v1alpha1.OutputSecretKeys{
+						ClientId:      "client_id",
+						Domain:        "domain",
+						Issuer:        "issuer",
+						TokenEndpoint: "token_endpoint",
+						JwksUri:       "jwks_uri",
+						Audience:      "audience",
+					}
+					client.Spec.ClientSecret.OutputAudience = "https://api.example.com"
+				})
+
+				It("should write the details of the client to the secret", func() {
+					secret := &corev1.Secret{}
+					Eventually(func() error {
+						return k8sClient.Get(
+							ctx,
+							types.NamespacedName{
+								Namespace: key.Namespace,
+								Name:      outputSecretName,
+							},
+							secret,
+						)
+					}).WithTimeout(timeout).Should(Succeed())
+
+					Expect(k8sClient.Get(ctx, key, client)).To(Succeed())
+
+					domain := string(secret.Data["domain"])
+					Expect(domain).ToNot(BeEmpty())
+					Expect(string(secret.Data[outputSecretKey])).ToNot(BeEmpty())
+					Expect(string(secret.Data["client_id"])).To(Equal(client.Status.Auth0Id))
+					Expect(string(secret.Data["issuer"])).To(Equal("https://" + domain + "/"))
+					Expect(string(secret.Data["token_endpoint"])).To(Equal("https://" + domain + "/oauth/token"))
+					Expect(string(secret.Data["jwks_uri"])).To(Equal("https://" + domain + "/.well-known/jwks.json"))
+					Expect(string(secret.Data["audience"])).To(Equal("https://api.example.com"))
+					Expect(secret.Data).ToNot(HaveKey("authorize_endpoint"))
+				})
+
+				It("should remove keys that are no longer configured", func() {
+					secretKey := types.NamespacedName{Namespace: key.Namespace, Name: outputSecretName}
+					secret := &corev1.Secret{}
+
+					Eventually(func() (map[string][]byte, error) {
+						err := k8sClient.Get(ctx, secretKey, secret)
+						return secret.Data, err
+					}).WithTimeout(timeout).Should(HaveKey("issuer"))
+
+					Expect(k8sClient.Get(ctx, key, client)).To(Succeed())
+					client.Spec.ClientSecret.OutputKeys.Issuer = ""
+					Expect(k8sClient.Update(ctx, client)).To(Succeed())
+
+					Eventually(func() (map[string][]byte, error) {
+						err := k8sClient.Get(ctx, secretKey, secret)
+						return secret.Data, err
+					}).WithTimeout(timeout).ShouldNot(HaveKey("issuer"))
+
+					Expect(secret.Data).To(HaveKey("client_id"))
+					Expect(secret.Data).To(HaveKey(outputSecretKey))
+				})
+			})
+
+			When("output templates are specified", func() {
+				var configMapName string
+
+				BeforeEach(func() {
+					configMapName = "test-output-config-" + time.Now().Format("20060102150405")
+					client.Spec.ClientSecret.OutputTemplates = map[string]string{
+						".env": "CLIENT_ID={{ .ClientId }}\nCLIENT_SECRET={{ .ClientSecret }}\n",
+					}
+					client.Spec.OutputConfigMap = &auth0v1alpha1.OutputConfigMap{
+						Name: configMapName,
+						Templates: map[string]string{
+							"config.json": `{"clientId":"{{ .ClientId }}","clientSecret":"{{ .ClientSecret }}"}`,
+						},
+					}
+				})
+
+				AfterEach(func() {
+					Expect(k8sClient.Delete(context.Background(), &corev1.ConfigMap{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      configMapName,
+							Namespace: key.Namespace,
+						},
+					})).To(Succeed())
+				})
+
+				It("should render the templates into the secret and config map", func() {
+					Eventually(func() bool {
+						if err := k8sClient.Get(ctx, key, client); err != nil {
+							return false
+						}
+						return meta.IsStatusConditionTrue(client.Status.Conditions, auth0v1alpha1.ConditionTypeConfigMapOutputReady)
+					}).WithTimeout(timeout).Should(BeTrue())
+
+					secret := &corev1.Secret{}
+					Expect(k8sClient.Get(
+						ctx,
+						types.NamespacedName{Namespace: key.Namespace, Name: outputSecretName},
+						secret,
+					)).To(Succeed())
+
+					clientSecret := string(secret.Data[outputSecretKey])
+					Expect(string(secret.Data[".env"])).To(Equal(
+						"CLIENT_ID=" + client.Status.Auth0Id + "\nCLIENT_SECRET=" + clientSecret + "\n",
+					))
+
+					configMap := &corev1.ConfigMap{}
+					Expect(k8sClient.Get(
+						ctx,
+						types.NamespacedName{Namespace: key.Namespace, Name: configMapName},
+						configMap,
+					)).To(Succeed())
+
+					Expect(configMap.Data["config.json"]).To(Equal(
+						`{"clientId":"` + client.Status.Auth0Id + `","clientSecret":""}`,
+					))
+					Expect(configMap.OwnerReferences).ToNot(BeEmpty())
+				})
+
+				It("should remove the rendered key of a renamed template", func() {
+					secretKey := types.NamespacedName{Namespace: key.Namespace, Name: outputSecretName}
+					secret := &corev1.Secret{}
+
+					Eventually(func() (map[string][]byte, error) {
+						err := k8sClient.Get(ctx, secretKey, secret)
+						return secret.Data, err
+					}).WithTimeout(timeout).Should(HaveKey(".env"))
+
+					Expect(k8sClient.Get(ctx, key, client)).To(Succeed())
+					client.Spec.ClientSecret.OutputTemplates = map[string]string{
+						"app.env": "CLIENT_ID={{ .ClientId }}\n",
+					}
+					Expect(k8sClient.Update(ctx, client)).To(Succeed())
+
+					Eventually(func() (map[string][]byte, error) {
+						err := k8sClient.Get(ctx, secretKey, secret)
+						return secret.Data, err
+					}).WithTimeout(timeout).Should(And(HaveKey("app.env"), Not(HaveKey(".env"))))
+				})
+			})
+		})
+	})
+
+	Describe("when an existing client is adopted", func() {
+		var existing *management.Client
+
+		BeforeEach(func() {
+			existing = &management.Client{
+				Name:        stringOrNil("test-suite-existing-client"),
+				Description: stringOrNil("Configured in the dashboard"),
+				AppType:     stringOrNil("spa"),
+				Callbacks:   &[]string{"https://example.com/callback"},
+				ClientMetadata: &map[string]interface{}{
+					"team": "identity",
+				},
+			}
+			Expect(auth0Api.Client.Create(ctx, existing)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			Expect(k8sClient.Delete(context.Background(), client)).To(Succeed())
+
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, key, &auth0v1alpha1.Client{})
+				return ctrlclient.IgnoreNotFound(err) == nil
+			}).WithTimeout(timeout).Should(BeTrue())
+
+			// Adopted clients are orphaned by default
+			Expect(auth0Api.Client.Delete(ctx, existing.GetClientID())).To(Succeed())
+		})
+
+		adoptFails := func() {
+			Expect(k8sClient.Create(ctx, client)).To(Succeed())
+
+			Eventually(func() string {
+				if err := k8sClient.Get(ctx, key, client); err != nil {
+					return ""
+				}
+
+				synced := meta.FindStatusCondition(client.Status.Conditions, auth0v1alpha1.ConditionTypeSynced)
+				if synced == nil {
+					return ""
+				}
+				return synced.Reason
+			}).WithTimeout(timeout).Should(Equal(EventReasonAdoptFailed))
+
+			Expect(client.Status.Auth0Id).To(BeEmpty())
+		}
+
+		adoptsExistingClient := func() {
+			Expect(k8sClient.Create(ctx, client)).To(Succeed())
+
+			Eventually(func() string {
+				if err := k8sClient.Get(ctx, key, client); err != nil {
+					return ""
+				}
+				return client.Status.Auth0Id
+			}).WithTimeout(timeout).Should(Equal(existing.GetClientID()))
+		}
+
+		When("the client ID is set in the spec", func() {
+			BeforeEach(func() {
+				client.Spec.Auth0Id = existing.GetClientID()
+			})
+
+			It("should manage the existing client instead of creating one", adoptsExistingClient)
+
+			It("should leave the fields the spec doesn't set as they are", func() {
+				client.Spec.Description = ""
+				client.Spec.Metadata = nil
+				adoptsExistingClient()
+
+				Eventually(func() string {
+					if err := k8sClient.Get(ctx, key, client); err != nil {
+						return ""
+					}
+
+					synced := meta.FindStatusCondition(client.Status.Conditions, auth0v1alpha1.ConditionTypeSynced)
+					if synced == nil || synced.Status != metav1.ConditionTrue {
+						return ""
+					}
+					return synced.Reason
+				}).WithTimeout(timeout).Should(Equal(EventReasonUpdated))
+
+				c, err := auth0Api.Client.Read(ctx, existing.GetClientID())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(c.GetDescription()).To(Equal("Configured in the dashboard"))
+				Expect(c.GetCallbacks()).To(Equal([]string{"https://example.com/callback"}))
+				Expect(c.GetClientMetadata()).To(HaveKeyWithValue("team", "identity"))
+				Expect(c.GetClientMetadata()).To(HaveKeyWithValue(auth0v1alpha1.OwnerMetadataKey, string(client.UID)))
+			})
+		})
+
+		When("the client ID is set in an annotation", func() {
+			BeforeEach(func() {
+				client.Annotations = map[string]string{
+					auth0v1alpha1.AdoptClientAnnotation: existing.GetClientID(),
+				}
+			})
+
+			It("should manage the existing client instead of creating one", adoptsExistingClient)
+		})
+
+		When("the client is the management client of the tenant", func() {
+			BeforeEach(func() {
+				client.Spec.Auth0Id = mustGetEnv("AUTH0_CLIENT_ID")
+			})
+
+			It("should refuse to adopt it", adoptFails)
+		})
+
+		When("the client is managed by another Client", func() {
+			BeforeEach(func() {
+				existing.ClientMetadata = &map[string]interface{}{
+					auth0v1alpha1.OwnerMetadataKey: "another-uid",
+				}
+				Expect(auth0Api.Client.Update(ctx, existing.GetClientID(), &management.Client{
+					ClientMetadata: existing.ClientMetadata,
+				})).To(Succeed())
+
+				client.Spec.Auth0Id = existing.GetClientID()
 			})
+
+			It("should refuse to adopt it", adoptFails)
 		})
 	})
 
@@ -306,4 +883,355 @@ var _ = Describe("Client controller", func() {
 			}).WithTimeout(timeout).WithPolling(1 * time.Second).Should(BeTrue())
 		})
 	})
+
+	Describe("when the Auth0 client is deleted outside of the operator", func() {
+		var deletedId string
+
+		JustBeforeEach(func() {
+			Expect(k8sClient.Create(ctx, client)).To(Succeed())
+
+			Eventually(func() bool {
+				if err := k8sClient.Get(ctx, key, client); err != nil {
+					return false
+				}
+				return client.Status.Auth0Id != ""
+			}).WithTimeout(timeout).Should(BeTrue())
+
+			deletedId = client.Status.Auth0Id
+			Expect(auth0Api.Client.Delete(ctx, deletedId)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			Expect(ctrlclient.IgnoreNotFound(k8sClient.Delete(context.Background(), client))).To(Succeed())
+
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, key, &auth0v1alpha1.Client{})
+				return ctrlclient.IgnoreNotFound(err) == nil
+			}).WithTimeout(timeout).Should(BeTrue())
+		})
+
+		It("should recreate the client in Auth0", func() {
+			// Trigger a reconcile without changing the spec
+			client.Annotations = map[string]string{"test": time.Now().String()}
+			Expect(k8sClient.Update(ctx, client)).To(Succeed())
+
+			Eventually(func() bool {
+				if err := k8sClient.Get(ctx, key, client); err != nil {
+					return false
+				}
+				return client.Status.Auth0Id != "" && client.Status.Auth0Id != deletedId
+			}).WithTimeout(timeout).Should(BeTrue())
+
+			_, err := auth0Api.Client.Read(ctx, client.Status.Auth0Id)
+			Expect(err).To(BeNil())
+		})
+
+		When("the not found policy is Report", func() {
+			BeforeEach(func() {
+				client.Spec.NotFoundPolicy = auth0v1alpha1.NotFoundPolicyReport
+			})
+
+			It("should report the client as not found in its status", func() {
+				client.Annotations = map[string]string{"test": time.Now().String()}
+				Expect(k8sClient.Update(ctx, client)).To(Succeed())
+
+				Eventually(func() string {
+					if err := k8sClient.Get(ctx, key, client); err != nil {
+						return ""
+					}
+
+					synced := meta.FindStatusCondition(client.Status.Conditions, auth0v1alpha1.ConditionTypeSynced)
+					if synced == nil || synced.Status != metav1.ConditionFalse {
+						return ""
+					}
+					return synced.Reason
+				}).WithTimeout(timeout).Should(Equal(ConditionReasonNotFound))
+
+				Expect(client.Status.Auth0Id).To(Equal(deletedId))
+			})
+		})
+
+		It("should remove the finalizer when the Client is deleted", func() {
+			Expect(k8sClient.Delete(context.Background(), client)).To(Succeed())
+
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, key, &auth0v1alpha1.Client{})
+				return ctrlclient.IgnoreNotFound(err) == nil
+			}).WithTimeout(timeout).Should(BeTrue())
+		})
+	})
+
+	Describe("when a client references a tenant that doesn't exist", func() {
+		JustBeforeEach(func() {
+			client.Spec.TenantRef = &auth0v1alpha1.TenantReference{Name: "non-existent-tenant"}
+			Expect(k8sClient.Create(ctx, client)).To(Succeed())
+		})
+
+		It("should report the tenant as unavailable and not block deletion", func() {
+			Eventually(func() string {
+				if err := k8sClient.Get(ctx, key, client); err != nil {
+					return ""
+				}
+
+				synced := meta.FindStatusCondition(client.Status.Conditions, auth0v1alpha1.ConditionTypeSynced)
+				if synced == nil {
+					return ""
+				}
+				return synced.Reason
+			}).WithTimeout(timeout).Should(Equal(ConditionReasonTenantUnavailable))
+
+			Expect(client.Status.Auth0Id).To(BeEmpty())
+			Expect(controllerutil.ContainsFinalizer(client, finalizerName)).To(BeFalse())
+
+			Expect(k8sClient.Delete(context.Background(), client)).To(Succeed())
+
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, key, &auth0v1alpha1.Client{})
+				return ctrlclient.IgnoreNotFound(err) == nil
+			}).WithTimeout(timeout).Should(BeTrue())
+		})
+	})
+
+	Describe("when a client references a tenant that doesn't allow its namespace", func() {
+		var tenant *auth0v1alpha1.Auth0Tenant
+
+		var allowedNamespaces []string
+
+		BeforeEach(func() {
+			allowedNamespaces = []string{"production"}
+		})
+
+		JustBeforeEach(func() {
+			tenant = &auth0v1alpha1.Auth0Tenant{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: key.Name,
+				},
+				Spec: auth0v1alpha1.Auth0TenantSpec{
+					Domain:   mustGetEnv("AUTH0_DOMAIN"),
+					ClientId: mustGetEnv("AUTH0_CLIENT_ID"),
+					ClientSecretRef: &auth0v1alpha1.NamespacedSecretRef{
+						Namespace: "default",
+						Name:      "test-suite-tenant",
+						Key:       "client-secret",
+					},
+					AllowedNamespaces: allowedNamespaces,
+				},
+			}
+			Expect(k8sClient.Create(ctx, tenant)).To(Succeed())
+
+			client.Spec.TenantRef = &auth0v1alpha1.TenantReference{Name: tenant.Name}
+			Expect(k8sClient.Create(ctx, client)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			Expect(k8sClient.Delete(context.Background(), client)).To(Succeed())
+
+			// The tenant is needed to delete a client that was created
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, key, &auth0v1alpha1.Client{})
+				return ctrlclient.IgnoreNotFound(err) == nil
+			}).WithTimeout(timeout).Should(BeTrue())
+
+			Expect(k8sClient.Delete(context.Background(), tenant)).To(Succeed())
+		})
+
+		It("should report the tenant as unavailable", func() {
+			Eventually(func() string {
+				if err := k8sClient.Get(ctx, key, client); err != nil {
+					return ""
+				}
+
+				synced := meta.FindStatusCondition(client.Status.Conditions, auth0v1alpha1.ConditionTypeSynced)
+				if synced == nil {
+					return ""
+				}
+				return synced.Reason
+			}).WithTimeout(timeout).Should(Equal(ConditionReasonTenantUnavailable))
+
+			Expect(client.Status.Auth0Id).To(BeEmpty())
+		})
+
+		When("the tenant doesn't list any namespaces", func() {
+			BeforeEach(func() {
+				allowedNamespaces = nil
+			})
+
+			It("should report the tenant as unavailable", func() {
+				Eventually(func() (string, error) {
+					err := k8sClient.Get(ctx, key, client)
+
+					synced := meta.FindStatusCondition(client.Status.Conditions, auth0v1alpha1.ConditionTypeSynced)
+					if synced == nil {
+						return "", err
+					}
+					return synced.Reason, err
+				}).WithTimeout(timeout).Should(Equal(ConditionReasonTenantUnavailable))
+			})
+		})
+
+		When("the tenant allows all namespaces", func() {
+			BeforeEach(func() {
+				allowedNamespaces = []string{auth0v1alpha1.AllNamespaces}
+			})
+
+			It("should create the client in Auth0", func() {
+				Eventually(func() (string, error) {
+					err := k8sClient.Get(ctx, key, client)
+					return client.Status.Auth0Id, err
+				}).WithTimeout(timeout).ShouldNot(BeEmpty())
+			})
+		})
+	})
+
+	Describe("when a client references a namespaced tenant", func() {
+		var tenant *auth0v1alpha1.NamespacedAuth0Tenant
+
+		JustBeforeEach(func() {
+			tenant = &auth0v1alpha1.NamespacedAuth0Tenant{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: auth0v1alpha1.NamespacedAuth0TenantSpec{
+					Domain:   mustGetEnv("AUTH0_DOMAIN"),
+					ClientId: mustGetEnv("AUTH0_CLIENT_ID"),
+					ClientSecretRef: &auth0v1alpha1.SecretRef{
+						Name: "test-suite-tenant",
+						Key:  "client-secret",
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, tenant)).To(Succeed())
+
+			client.Spec.TenantRef = &auth0v1alpha1.TenantReference{
+				Kind: auth0v1alpha1.NamespacedAuth0TenantKind,
+				Name: tenant.Name,
+			}
+			Expect(k8sClient.Create(ctx, client)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			Expect(k8sClient.Delete(context.Background(), client)).To(Succeed())
+
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, key, &auth0v1alpha1.Client{})
+				return ctrlclient.IgnoreNotFound(err) == nil
+			}).WithTimeout(timeout).Should(BeTrue())
+
+			Expect(k8sClient.Delete(context.Background(), tenant)).To(Succeed())
+		})
+
+		It("should create the client in the tenant", func() {
+			Eventually(func() bool {
+				if err := k8sClient.Get(ctx, key, client); err != nil {
+					return false
+				}
+				return client.Status.Auth0Id != ""
+			}).WithTimeout(timeout).Should(BeTrue())
+
+			_, err := auth0Api.Client.Read(ctx, client.Status.Auth0Id)
+			Expect(err).To(BeNil())
+		})
+	})
+
+	Describe("when the credentials of a client's tenant are deleted before the client", func() {
+		var tenant *auth0v1alpha1.NamespacedAuth0Tenant
+		var credentials *corev1.Secret
+
+		JustBeforeEach(func() {
+			suiteCredentials := &corev1.Secret{}
+			Expect(k8sClient.Get(
+				ctx,
+				types.NamespacedName{Namespace: "default", Name: "test-suite-tenant"},
+				suiteCredentials,
+			)).To(Succeed())
+
+			credentials = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name + "-tenant",
+					Namespace: key.Namespace,
+				},
+				Data: suiteCredentials.Data,
+			}
+			Expect(k8sClient.Create(ctx, credentials)).To(Succeed())
+
+			tenant = &auth0v1alpha1.NamespacedAuth0Tenant{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: auth0v1alpha1.NamespacedAuth0TenantSpec{
+					Domain:   mustGetEnv("AUTH0_DOMAIN"),
+					ClientId: mustGetEnv("AUTH0_CLIENT_ID"),
+					ClientSecretRef: &auth0v1alpha1.SecretRef{
+						Name: credentials.Name,
+						Key:  "client-secret",
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, tenant)).To(Succeed())
+
+			client.Spec.TenantRef = &auth0v1alpha1.TenantReference{
+				Kind: auth0v1alpha1.NamespacedAuth0TenantKind,
+				Name: tenant.Name,
+			}
+			Expect(k8sClient.Create(ctx, client)).To(Succeed())
+
+			Eventually(func() bool {
+				if err := k8sClient.Get(ctx, key, client); err != nil {
+					return false
+				}
+				return client.Status.Auth0Id != "" && controllerutil.ContainsFinalizer(client, finalizerName)
+			}).WithTimeout(timeout).Should(BeTrue())
+		})
+
+		AfterEach(func() {
+			Expect(auth0Api.Client.Delete(ctx, client.Status.Auth0Id)).To(Succeed())
+			Expect(k8sClient.Delete(context.Background(), tenant)).To(Succeed())
+		})
+
+		It("should remove the finalizer and leave the client in Auth0", func() {
+			Expect(k8sClient.Delete(ctx, credentials)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, client)).To(Succeed())
+
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, key, &auth0v1alpha1.Client{})
+				return ctrlclient.IgnoreNotFound(err) == nil
+			}).WithTimeout(timeout).Should(BeTrue())
+
+			_, err := auth0Api.Client.Read(ctx, client.Status.Auth0Id)
+			Expect(err).To(BeNil())
+		})
+	})
+
+	Describe("when a client with the Orphan deletion policy is deleted", func() {
+		JustBeforeEach(func() {
+			client.Spec.DeletionPolicy = auth0v1alpha1.DeletionPolicyOrphan
+			Expect(k8sClient.Create(ctx, client)).To(Succeed())
+
+			Eventually(func() bool {
+				if err := k8sClient.Get(ctx, key, client); err != nil {
+					return false
+				}
+				return client.Status.Auth0Id != ""
+			}).WithTimeout(timeout).Should(BeTrue())
+		})
+
+		AfterEach(func() {
+			Expect(auth0Api.Client.Delete(ctx, client.Status.Auth0Id)).To(Succeed())
+		})
+
+		It("should leave the client in Auth0", func() {
+			Expect(k8sClient.Delete(context.Background(), client)).To(Succeed())
+
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, key, &auth0v1alpha1.Client{})
+				return ctrlclient.IgnoreNotFound(err) == nil
+			}).WithTimeout(timeout).Should(BeTrue())
+
+			c, err := auth0Api.Client.Read(ctx, client.Status.Auth0Id)
+			Expect(err).To(BeNil())
+			Expect(c.GetClientMetadata()).ToNot(HaveKey(auth0v1alpha1.OwnerMetadataKey))
+		})
+	})
 })
@@ -0,0 +1,361 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/auth0/go-auth0/management"
+	auth0v1alpha1 "github.com/rgracey/auth0-operator/api/v1alpha1"
+)
+
+// ClientGrantReconciler reconciles a ClientGrant object
+type ClientGrantReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	Tenants  *Tenants
+}
+
+//+kubebuilder:rbac:groups=auth0.gracey.io,resources=clientgrants,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=auth0.gracey.io,resources=clientgrants/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=auth0.gracey.io,resources=clientgrants/finalizers,verbs=update
+
+// Reconcile moves the Auth0 client grant towards the state specified by
+// the ClientGrant object
+func (r *ClientGrantReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	// Fetch the ClientGrant instance
+	instance := &auth0v1alpha1.ClientGrant{}
+	if err := r.Get(ctx, req.NamespacedName, instance); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	// Check if the ClientGrant instance is being deleted, and run finalizer logic.
+	// This comes before resolving the tenant so that deletion isn't blocked
+	// by a tenant that can no longer be used
+	if instance.IsBeingDeleted() {
+		logger.Info("deleting client grant", "audience", instance.Spec.Audience)
+		return ctrl.Result{}, r.handleFinalizer(ctx, instance)
+	}
+
+	// The ClientGrant is managed in the Auth0 tenant it references. A finalizer
+	// isn't added until the tenant can be used to clean up after it
+	api, err := r.Tenants.Api(ctx, instance.Namespace, instance.Spec.TenantRef)
+
+	if err != nil {
+		logger.Error(err, "unable to get Auth0 tenant")
+		setSyncedCondition(instance, metav1.ConditionFalse, ConditionReasonTenantUnavailable, err.Error())
+
+		if statusErr := updateSyncedStatus(ctx, r.Client, instance, "ClientGrant"); statusErr != nil {
+			logger.Error(statusErr, "unable to update client grant status", "audience", instance.Spec.Audience)
+		}
+
+		return ctrl.Result{}, err
+	}
+
+	if !hasFinalizer(instance) {
+		return ctrl.Result{Requeue: true}, addFinalizer(ctx, r.Client, instance)
+	}
+
+	result, err := r.reconcileClientGrant(ctx, api, instance)
+
+	if statusErr := updateSyncedStatus(ctx, r.Client, instance, "ClientGrant"); statusErr != nil {
+		logger.Error(statusErr, "unable to update client grant status", "audience", instance.Spec.Audience)
+
+		if err == nil {
+			err = statusErr
+		}
+	}
+
+	return result, err
+}
+
+// reconcileClientGrant moves the Auth0 client grant towards the state
+// specified by the ClientGrant, recording the outcome in its Synced
+// condition
+func (r *ClientGrantReconciler) reconcileClientGrant(
+	ctx context.Context,
+	api *management.Management,
+	instance *auth0v1alpha1.ClientGrant,
+) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	// scopes must be non-nil
+	scopes := instance.Spec.Scopes
+	if scopes == nil {
+		scopes = []string{}
+	}
+
+	clientID, err := resolveClientReference(
+		ctx,
+		r.Client,
+		r.Tenants,
+		instance.Namespace,
+		instance.Spec.TenantRef,
+		instance.Spec.ClientRef,
+	)
+
+	if err != nil {
+		logger.Error(err, "unable to resolve client", "client", instance.Spec.ClientRef.Name)
+		r.Recorder.Event(instance, "Warning", EventReasonCreateFailed, err.Error())
+		setSyncedCondition(instance, metav1.ConditionFalse, EventReasonCreateFailed, err.Error())
+		return ctrl.Result{}, err
+	}
+
+	// The grant is reconciled again once the Client has been created
+	if clientID == "" {
+		logger.Info("waiting for client to be created", "client", instance.Spec.ClientRef.Name)
+		setSyncedCondition(
+			instance,
+			metav1.ConditionFalse,
+			ConditionReasonClientPending,
+			fmt.Sprintf("Waiting for Client %s to be created", instance.Spec.ClientRef.Name),
+		)
+		return ctrl.Result{}, nil
+	}
+
+	// Reuse a grant created by a previous reconcile whose status update
+	// failed. A client can only be granted access to an audience once
+	if instance.Auth0Id() == "" && instance.Status.PendingCreate {
+		existing, err := api.ClientGrant.List(
+			ctx,
+			management.Parameter("audience", instance.Spec.Audience),
+			management.Parameter("client_id", clientID),
+		)
+
+		if err != nil {
+			logger.Error(err, "unable to search for existing client grant", "audience", instance.Spec.Audience)
+			r.Recorder.Event(instance, "Warning", EventReasonCreateFailed, err.Error())
+			setSyncedCondition(instance, metav1.ConditionFalse, EventReasonCreateFailed, err.Error())
+			return ctrl.Result{}, err
+		}
+
+		if len(existing.ClientGrants) > 0 {
+			g := existing.ClientGrants[0]
+			logger.Info("found existing client grant", "audience", instance.Spec.Audience, "Auth0 id", g.GetID())
+			instance.Status.Auth0Id = g.GetID()
+			instance.Status.ClientId = clientID
+		}
+
+		instance.Status.PendingCreate = false
+	}
+
+	// Create the ClientGrant if it doesn't exist
+	if instance.Auth0Id() == "" {
+		g := &management.ClientGrant{
+			ClientID: &clientID,
+			Audience: &instance.Spec.Audience,
+			Scope:    scopes,
+		}
+
+		// Record that a create is pending first, so the grant is looked up
+		// if its ID can't be recorded after creating it
+		instance.Status.PendingCreate = true
+
+		if err := r.Status().Update(ctx, instance); err != nil {
+			logger.Error(err, "unable to update client grant status", "audience", instance.Spec.Audience)
+			setSyncedCondition(instance, metav1.ConditionFalse, EventReasonCreateFailed, err.Error())
+			return ctrl.Result{}, err
+		}
+
+		logger.Info("creating client grant", "audience", instance.Spec.Audience, "client", clientID)
+		err = api.ClientGrant.Create(ctx, g)
+
+		if err != nil {
+			logger.Error(err, "unable to create client grant", "audience", instance.Spec.Audience)
+			r.Recorder.Event(instance, "Warning", EventReasonCreateFailed, err.Error())
+			setSyncedCondition(instance, metav1.ConditionFalse, EventReasonCreateFailed, err.Error())
+
+			// Auth0 rejected the request, so no grant was created
+			if isClientError(err) {
+				instance.Status.PendingCreate = false
+			}
+
+			return ctrl.Result{}, err
+		}
+
+		logger.Info("created client grant", "audience", instance.Spec.Audience, "Auth0 id", g.GetID())
+
+		// If this fails the grant is looked up next time
+		instance.Status.Auth0Id = g.GetID()
+		instance.Status.ClientId = clientID
+		instance.Status.PendingCreate = false
+		apiErr := r.Status().Update(ctx, instance)
+
+		if apiErr != nil {
+			logger.Error(apiErr, "unable to update client grant status", "audience", instance.Spec.Audience)
+			instance.Status.Auth0Id = ""
+			instance.Status.ClientId = ""
+			instance.Status.PendingCreate = true
+			setSyncedCondition(instance, metav1.ConditionFalse, EventReasonCreateFailed, apiErr.Error())
+			return ctrl.Result{}, apiErr
+		}
+
+		r.Recorder.Event(
+			instance,
+			"Normal",
+			EventReasonCreated,
+			fmt.Sprintf(
+				"Created client grant for %s (ID: %s)",
+				instance.Spec.Audience,
+				instance.Status.Auth0Id,
+			),
+		)
+
+		setSyncedCondition(instance, metav1.ConditionTrue, EventReasonCreated, "Client grant created in Auth0")
+
+		return ctrl.Result{}, nil
+	}
+
+	// A grant can't be moved to another client, so if the referenced Client
+	// has been recreated in Auth0 the grant is recreated for the new client
+	if instance.Status.ClientId != "" && instance.Status.ClientId != clientID {
+		logger.Info(
+			"client changed, recreating client grant",
+			"audience", instance.Spec.Audience,
+			"old client", instance.Status.ClientId,
+			"client", clientID,
+		)
+
+		err = api.ClientGrant.Delete(ctx, instance.Auth0Id())
+
+		// Auth0 deletes the grants of a client when the client is deleted, so
+		// the grant may already be gone
+		if err != nil && !isNotFound(err) {
+			logger.Error(err, "unable to delete client grant", "audience", instance.Spec.Audience)
+			r.Recorder.Event(instance, "Warning", EventReasonDeleteFailed, err.Error())
+			setSyncedCondition(instance, metav1.ConditionFalse, EventReasonDeleteFailed, err.Error())
+			return ctrl.Result{}, err
+		}
+
+		return ctrl.Result{Requeue: true}, r.forgetClientGrant(ctx, instance)
+	}
+
+	// Grants can only be read by listing them, so the list is narrowed to
+	// the grant's client
+	current, err := api.ClientGrant.Read(ctx, instance.Auth0Id(), management.Parameter("client_id", clientID))
+
+	// The grant was deleted outside of the operator, e.g. along with its
+	// client, so it's created again
+	if isNotFound(err) {
+		logger.Info("client grant not found, recreating", "audience", instance.Spec.Audience, "Auth0 id", instance.Auth0Id())
+		return ctrl.Result{Requeue: true}, r.forgetClientGrant(ctx, instance)
+	}
+
+	if err != nil {
+		logger.Error(err, "unable to fetch client grant", "audience", instance.Spec.Audience)
+		r.Recorder.Event(instance, "Warning", EventReasonUpdateFailed, err.Error())
+		setSyncedCondition(instance, metav1.ConditionFalse, EventReasonUpdateFailed, err.Error())
+		return ctrl.Result{}, err
+	}
+
+	// Auth0 only allows the scopes of a grant to be updated
+	if !sameElements(scopes, current.Scope) {
+		logger.Info("updating client grant", "audience", instance.Spec.Audience)
+
+		if err := api.ClientGrant.Update(ctx, instance.Auth0Id(), &management.ClientGrant{Scope: scopes}); err != nil {
+			logger.Error(err, "unable to update client grant", "audience", instance.Spec.Audience)
+			r.Recorder.Event(instance, "Warning", EventReasonUpdateFailed, err.Error())
+			setSyncedCondition(instance, metav1.ConditionFalse, EventReasonUpdateFailed, err.Error())
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Grants created before the client was tracked record it now, so a
+	// later change of client can be detected
+	if instance.Status.ClientId == "" {
+		instance.Status.ClientId = current.GetClientID()
+	}
+
+	setSyncedCondition(instance, metav1.ConditionTrue, EventReasonUpdated, "Client grant is in sync with Auth0")
+
+	return ctrl.Result{}, nil
+}
+
+// forgetClientGrant clears the Auth0 grant from the status of the
+// ClientGrant so that it's created again
+func (r *ClientGrantReconciler) forgetClientGrant(ctx context.Context, instance *auth0v1alpha1.ClientGrant) error {
+	instance.Status.Auth0Id = ""
+	instance.Status.ClientId = ""
+	return r.Status().Update(ctx, instance)
+}
+
+// clientGrantsForClient maps a Client to the ClientGrants that reference it
+func (r *ClientGrantReconciler) clientGrantsForClient(ctx context.Context, obj client.Object) []reconcile.Request {
+	grants := &auth0v1alpha1.ClientGrantList{}
+	if err := r.List(ctx, grants, client.InNamespace(obj.GetNamespace())); err != nil {
+		log.FromContext(ctx).Error(err, "unable to list client grants")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, g := range grants.Items {
+		if g.Spec.ClientRef.Name == obj.GetName() {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Namespace: g.Namespace,
+					Name:      g.Name,
+				},
+			})
+		}
+	}
+
+	return requests
+}
+
+// clientGrantsForTenantSecret maps a Secret to the ClientGrants managed in the
+// tenants whose credentials it holds
+func (r *ClientGrantReconciler) clientGrantsForTenantSecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	return requestsForTenantSecret(ctx, r.Client, r.Tenants, &auth0v1alpha1.ClientGrantList{}, obj)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ClientGrantReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	err := indexByTenant(mgr, r.Tenants, &auth0v1alpha1.ClientGrant{}, func(obj client.Object) *auth0v1alpha1.TenantReference {
+		return obj.(*auth0v1alpha1.ClientGrant).Spec.TenantRef
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&auth0v1alpha1.ClientGrant{}).
+		Watches(
+			&auth0v1alpha1.Client{},
+			handler.EnqueueRequestsFromMapFunc(r.clientGrantsForClient),
+		).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.clientGrantsForTenantSecret),
+		).
+		Complete(rateLimitAware(r))
+}
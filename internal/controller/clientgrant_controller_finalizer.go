@@ -0,0 +1,53 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	auth0v1alpha1 "github.com/rgracey/auth0-operator/api/v1alpha1"
+)
+
+// handleFinalizer handles the finalizer logic for the ClientGrant
+func (r *ClientGrantReconciler) handleFinalizer(
+	ctx context.Context,
+	instance *auth0v1alpha1.ClientGrant,
+) error {
+	if !hasFinalizer(instance) {
+		return nil
+	}
+
+	if instance.Auth0Id() == "" {
+		return removeFinalizer(ctx, r.Client, instance)
+	}
+
+	api, err := deletionApi(ctx, r.Tenants, r.Recorder, instance, instance.Spec.TenantRef)
+	if err != nil {
+		return err
+	}
+
+	if api == nil {
+		return removeFinalizer(ctx, r.Client, instance)
+	}
+
+	err = api.ClientGrant.Delete(ctx, instance.Status.Auth0Id)
+
+	// Auth0 deletes the grants of a client when the client is deleted, so
+	// the grant may already be gone
+	if err != nil && !isNotFound(err) {
+		r.Recorder.Event(instance, "Warning", EventReasonDeleteFailed, err.Error())
+		return err
+	}
+
+	r.Recorder.Event(
+		instance,
+		"Normal",
+		EventReasonDeleted,
+		fmt.Sprintf(
+			"Deleted client grant for %s (ID: %s)",
+			instance.Spec.Audience,
+			instance.Status.Auth0Id,
+		),
+	)
+
+	return removeFinalizer(ctx, r.Client, instance)
+}
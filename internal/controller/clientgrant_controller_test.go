@@ -0,0 +1,225 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/auth0/go-auth0/management"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	auth0v1alpha1 "github.com/rgracey/auth0-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("ClientGrant controller", func() {
+	var key types.NamespacedName
+
+	var client *auth0v1alpha1.Client
+
+	var clientGrant *auth0v1alpha1.ClientGrant
+
+	var resourceServer *management.ResourceServer
+
+	BeforeEach(func() {
+		suffix := time.Now().Format("20060102150405")
+
+		// The API being granted access to
+		identifier := "https://test-suite-grant-" + suffix + ".example.com"
+		resourceServer = &management.ResourceServer{
+			Name:       &identifier,
+			Identifier: &identifier,
+			Scopes: &[]management.ResourceServerScope{
+				{Value: stringOrNil("read:test")},
+				{Value: stringOrNil("write:test")},
+			},
+		}
+		Expect(auth0Api.ResourceServer.Create(ctx, resourceServer)).To(Succeed())
+
+		key = types.NamespacedName{
+			Name:      "test-client-grant-" + suffix,
+			Namespace: "default",
+		}
+		client = &auth0v1alpha1.Client{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+			},
+			Spec: auth0v1alpha1.ClientSpec{
+				Name: "test-suite-client",
+				Type: "non_interactive",
+			},
+		}
+		clientGrant = &auth0v1alpha1.ClientGrant{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+			},
+			Spec: auth0v1alpha1.ClientGrantSpec{
+				ClientRef: auth0v1alpha1.ClientReference{
+					Name: client.Name,
+				},
+				Audience: identifier,
+				Scopes:   []string{"read:test"},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.Delete(context.Background(), clientGrant)).To(Succeed())
+		Expect(k8sClient.Delete(context.Background(), client)).To(Succeed())
+
+		Eventually(func() bool {
+			err := k8sClient.Get(ctx, key, &auth0v1alpha1.ClientGrant{})
+			return ctrlclient.IgnoreNotFound(err) == nil
+		}).WithTimeout(timeout).Should(BeTrue())
+
+		Expect(auth0Api.ResourceServer.Delete(ctx, resourceServer.GetID())).To(Succeed())
+	})
+
+	Describe("when a client grant is created", func() {
+		JustBeforeEach(func() {
+			// Create the grant before the client, so it must wait for it
+			Expect(k8sClient.Create(ctx, clientGrant)).To(Succeed())
+			Expect(k8sClient.Create(ctx, client)).To(Succeed())
+
+			Eventually(func() bool {
+				if err := k8sClient.Get(ctx, key, clientGrant); err != nil {
+					return false
+				}
+				return clientGrant.Status.Auth0Id != ""
+			}).WithTimeout(timeout).Should(BeTrue())
+
+			Expect(k8sClient.Get(ctx, key, client)).To(Succeed())
+		})
+
+		It("should create a client grant in Auth0 with the correct values", func() {
+			g, err := auth0Api.ClientGrant.Read(ctx, clientGrant.Status.Auth0Id)
+			Expect(err).To(BeNil())
+			Expect(g.GetClientID()).To(Equal(client.Status.Auth0Id))
+			Expect(g.GetAudience()).To(Equal(clientGrant.Spec.Audience))
+			Expect(g.Scope).To(ConsistOf("read:test"))
+		})
+
+		It("should mark the client grant as ready", func() {
+			Eventually(func() bool {
+				if err := k8sClient.Get(ctx, key, clientGrant); err != nil {
+					return false
+				}
+				return meta.IsStatusConditionTrue(clientGrant.Status.Conditions, auth0v1alpha1.ConditionTypeReady)
+			}).WithTimeout(timeout).Should(BeTrue())
+
+			Expect(clientGrant.Status.PendingCreate).To(BeFalse())
+		})
+
+		It("should reuse the client grant it created if its ID is lost", func() {
+			createdId := clientGrant.Status.Auth0Id
+
+			// Lose the ID as if the status update after creating it failed
+			clientGrant.Status.Auth0Id = ""
+			clientGrant.Status.ClientId = ""
+			clientGrant.Status.PendingCreate = true
+			Expect(k8sClient.Status().Update(ctx, clientGrant)).To(Succeed())
+
+			Eventually(func() string {
+				if err := k8sClient.Get(ctx, key, clientGrant); err != nil {
+					return ""
+				}
+				return clientGrant.Status.Auth0Id
+			}).WithTimeout(timeout).Should(Equal(createdId))
+		})
+
+		When("the scopes are changed", func() {
+			It("should update the scopes in Auth0", func() {
+				clientGrant.Spec.Scopes = []string{"read:test", "write:test"}
+				Expect(k8sClient.Update(ctx, clientGrant)).To(Succeed())
+
+				Eventually(func() []string {
+					g, err := auth0Api.ClientGrant.Read(ctx, clientGrant.Status.Auth0Id)
+					if err != nil {
+						return nil
+					}
+					return g.Scope
+				}).WithTimeout(timeout).WithPolling(1 * time.Second).Should(ConsistOf("read:test", "write:test"))
+			})
+		})
+
+		When("the client is recreated in Auth0", func() {
+			BeforeEach(func() {
+				client.Spec.SyncInterval = &metav1.Duration{Duration: time.Second}
+			})
+
+			It("should recreate the client grant for the new client", func() {
+				oldClientId := client.Status.Auth0Id
+				Expect(clientGrant.Status.ClientId).To(Equal(oldClientId))
+				Expect(auth0Api.Client.Delete(ctx, oldClientId)).To(Succeed())
+
+				Eventually(func() (string, error) {
+					err := k8sClient.Get(ctx, key, clientGrant)
+					return clientGrant.Status.ClientId, err
+				}).WithTimeout(timeout).ShouldNot(Or(BeEmpty(), Equal(oldClientId)))
+
+				Expect(k8sClient.Get(ctx, key, client)).To(Succeed())
+				Expect(clientGrant.Status.ClientId).To(Equal(client.Status.Auth0Id))
+
+				g, err := auth0Api.ClientGrant.Read(ctx, clientGrant.Status.Auth0Id)
+				Expect(err).To(BeNil())
+				Expect(g.GetClientID()).To(Equal(client.Status.Auth0Id))
+			})
+		})
+	})
+
+	Describe("when the client is managed in another tenant", func() {
+		var tenant *auth0v1alpha1.NamespacedAuth0Tenant
+
+		JustBeforeEach(func() {
+			tenant = &auth0v1alpha1.NamespacedAuth0Tenant{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: auth0v1alpha1.NamespacedAuth0TenantSpec{
+					Domain:   "other-tenant.example.com",
+					ClientId: mustGetEnv("AUTH0_CLIENT_ID"),
+					ClientSecretRef: &auth0v1alpha1.SecretRef{
+						Name: "test-suite-tenant",
+						Key:  "client-secret",
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, tenant)).To(Succeed())
+
+			client.Spec.TenantRef = &auth0v1alpha1.TenantReference{
+				Kind: auth0v1alpha1.NamespacedAuth0TenantKind,
+				Name: tenant.Name,
+			}
+			Expect(k8sClient.Create(ctx, client)).To(Succeed())
+			Expect(k8sClient.Create(ctx, clientGrant)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			Expect(k8sClient.Delete(context.Background(), tenant)).To(Succeed())
+		})
+
+		It("should refuse to grant the client access", func() {
+			Eventually(func() ([]string, error) {
+				events := &corev1.EventList{}
+				err := k8sClient.List(ctx, events, ctrlclient.InNamespace(key.Namespace))
+
+				var messages []string
+				for _, e := range events.Items {
+					if e.InvolvedObject.Kind == "ClientGrant" && e.InvolvedObject.Name == clientGrant.Name {
+						messages = append(messages, e.Message)
+					}
+				}
+				return messages, err
+			}).WithTimeout(timeout).Should(ContainElement(ContainSubstring("is managed in tenant other-tenant.example.com")))
+
+			Expect(k8sClient.Get(ctx, key, clientGrant)).To(Succeed())
+			Expect(clientGrant.Status.Auth0Id).To(BeEmpty())
+		})
+	})
+})
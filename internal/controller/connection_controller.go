@@ -0,0 +1,406 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/auth0/go-auth0/management"
+	auth0v1alpha1 "github.com/rgracey/auth0-operator/api/v1alpha1"
+)
+
+// connectionSecretRefIndex indexes Connections by the names of the Secrets
+// their options are read from
+const connectionSecretRefIndex = ".spec.secretRefs"
+
+// ConnectionReconciler reconciles a Connection object
+type ConnectionReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	Tenants  *Tenants
+}
+
+//+kubebuilder:rbac:groups=auth0.gracey.io,resources=connections,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=auth0.gracey.io,resources=connections/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=auth0.gracey.io,resources=connections/finalizers,verbs=update
+//+kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch
+
+// Reconcile moves the Auth0 connection towards the state specified by
+// the Connection object
+func (r *ConnectionReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	// Fetch the Connection instance
+	instance := &auth0v1alpha1.Connection{}
+	if err := r.Get(ctx, req.NamespacedName, instance); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	// Check if the Connection instance is being deleted, and run finalizer logic.
+	// This comes before resolving the tenant so that deletion isn't blocked
+	// by a tenant that can no longer be used
+	if instance.IsBeingDeleted() {
+		logger.Info("deleting connection", "name", instance.Spec.Name)
+		return ctrl.Result{}, r.handleFinalizer(ctx, instance)
+	}
+
+	// The Connection is managed in the Auth0 tenant it references. A finalizer
+	// isn't added until the tenant can be used to clean up after it
+	api, err := r.Tenants.Api(ctx, instance.Namespace, instance.Spec.TenantRef)
+
+	if err != nil {
+		logger.Error(err, "unable to get Auth0 tenant")
+		setSyncedCondition(instance, metav1.ConditionFalse, ConditionReasonTenantUnavailable, err.Error())
+
+		if statusErr := updateSyncedStatus(ctx, r.Client, instance, "Connection"); statusErr != nil {
+			logger.Error(statusErr, "unable to update connection status", "name", instance.Spec.Name)
+		}
+
+		return ctrl.Result{}, err
+	}
+
+	if !hasFinalizer(instance) {
+		return ctrl.Result{Requeue: true}, addFinalizer(ctx, r.Client, instance)
+	}
+
+	result, err := r.reconcileConnection(ctx, api, instance)
+
+	if statusErr := updateSyncedStatus(ctx, r.Client, instance, "Connection"); statusErr != nil {
+		logger.Error(statusErr, "unable to update connection status", "name", instance.Spec.Name)
+
+		if err == nil {
+			err = statusErr
+		}
+	}
+
+	return result, err
+}
+
+// reconcileConnection moves the Auth0 connection towards the state specified
+// by the Connection, recording the outcome in its Synced condition
+func (r *ConnectionReconciler) reconcileConnection(
+	ctx context.Context,
+	api *management.Management,
+	instance *auth0v1alpha1.Connection,
+) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	enabledClients, err := resolveClientReferences(
+		ctx,
+		r.Client,
+		r.Tenants,
+		instance.Namespace,
+		instance.Spec.TenantRef,
+		instance.Spec.EnabledClients,
+	)
+
+	if err != nil {
+		logger.Error(err, "unable to resolve enabled clients", "name", instance.Spec.Name)
+		r.Recorder.Event(instance, "Warning", EventReasonUpdateFailed, err.Error())
+		setSyncedCondition(instance, metav1.ConditionFalse, EventReasonUpdateFailed, err.Error())
+		return ctrl.Result{}, err
+	}
+
+	// Reuse a connection created by a previous reconcile whose status update
+	// failed. Connection names are unique in a tenant
+	if instance.Auth0Id() == "" && instance.Status.PendingCreate {
+		existing, err := api.Connection.ReadByName(ctx, instance.Spec.Name)
+
+		if err != nil && !isNotFound(err) {
+			logger.Error(err, "unable to search for existing connection", "name", instance.Spec.Name)
+			r.Recorder.Event(instance, "Warning", EventReasonCreateFailed, err.Error())
+			setSyncedCondition(instance, metav1.ConditionFalse, EventReasonCreateFailed, err.Error())
+			return ctrl.Result{}, err
+		}
+
+		if err == nil {
+			logger.Info("found existing connection", "name", instance.Spec.Name, "Auth0 id", existing.GetID())
+			instance.Status.Auth0Id = existing.GetID()
+		}
+
+		instance.Status.PendingCreate = false
+	}
+
+	// Create the Connection if it doesn't exist
+	if instance.Auth0Id() == "" {
+		options, err := r.connectionOptions(ctx, instance, nil)
+
+		if err != nil {
+			r.Recorder.Event(instance, "Warning", EventReasonCreateFailed, err.Error())
+			setSyncedCondition(instance, metav1.ConditionFalse, EventReasonCreateFailed, err.Error())
+			return ctrl.Result{}, err
+		}
+
+		c := &management.Connection{
+			Name:           &instance.Spec.Name,
+			DisplayName:    stringOrNil(instance.Spec.DisplayName),
+			Strategy:       &instance.Spec.Strategy,
+			EnabledClients: &enabledClients,
+			Options:        options,
+		}
+
+		// Record that a create is pending first, so the connection is looked
+		// up if its ID can't be recorded after creating it
+		instance.Status.PendingCreate = true
+
+		if err := r.Status().Update(ctx, instance); err != nil {
+			logger.Error(err, "unable to update connection status", "name", instance.Spec.Name)
+			setSyncedCondition(instance, metav1.ConditionFalse, EventReasonCreateFailed, err.Error())
+			return ctrl.Result{}, err
+		}
+
+		logger.Info("creating connection", "name", instance.Spec.Name)
+		err = api.Connection.Create(ctx, c)
+
+		if err != nil {
+			logger.Error(err, "unable to create connection", "name", instance.Spec.Name)
+			r.Recorder.Event(instance, "Warning", EventReasonCreateFailed, err.Error())
+			setSyncedCondition(instance, metav1.ConditionFalse, EventReasonCreateFailed, err.Error())
+
+			// Auth0 rejected the request, so no connection was created
+			if isClientError(err) {
+				instance.Status.PendingCreate = false
+			}
+
+			return ctrl.Result{}, err
+		}
+
+		logger.Info("created connection", "name", instance.Spec.Name, "Auth0 id", c.GetID())
+
+		// If this fails the connection is looked up next time
+		instance.Status.Auth0Id = c.GetID()
+		instance.Status.PendingCreate = false
+		apiErr := r.Status().Update(ctx, instance)
+
+		if apiErr != nil {
+			logger.Error(apiErr, "unable to update connection status", "name", instance.Spec.Name)
+			instance.Status.Auth0Id = ""
+			instance.Status.PendingCreate = true
+			setSyncedCondition(instance, metav1.ConditionFalse, EventReasonCreateFailed, apiErr.Error())
+			return ctrl.Result{}, apiErr
+		}
+
+		r.Recorder.Event(
+			instance,
+			"Normal",
+			EventReasonCreated,
+			fmt.Sprintf(
+				"Created connection %s (ID: %s)",
+				instance.Spec.Name,
+				instance.Status.Auth0Id,
+			),
+		)
+
+		setSyncedCondition(instance, metav1.ConditionTrue, EventReasonCreated, "Connection created in Auth0")
+
+		return ctrl.Result{}, nil
+	}
+
+	current, err := api.Connection.Read(ctx, instance.Auth0Id())
+
+	if err != nil {
+		logger.Error(err, "unable to fetch connection", "name", instance.Spec.Name)
+		r.Recorder.Event(instance, "Warning", EventReasonUpdateFailed, err.Error())
+		setSyncedCondition(instance, metav1.ConditionFalse, EventReasonUpdateFailed, err.Error())
+		return ctrl.Result{}, err
+	}
+
+	// The options are built on top of the current options, so they're
+	// compared with a copy taken beforehand
+	currentOptions, _ := toJSONValue(current.Options)
+
+	// Auth0 replaces the options as a whole, so they're built on top of
+	// the current options to keep those that aren't managed here
+	options, err := r.connectionOptions(ctx, instance, current.Options)
+
+	if err != nil {
+		r.Recorder.Event(instance, "Warning", EventReasonUpdateFailed, err.Error())
+		setSyncedCondition(instance, metav1.ConditionFalse, EventReasonUpdateFailed, err.Error())
+		return ctrl.Result{}, err
+	}
+
+	desiredOptions, err := toJSONValue(options)
+
+	changed := err != nil ||
+		!reflect.DeepEqual(desiredOptions, currentOptions) ||
+		!sameElements(enabledClients, current.GetEnabledClients()) ||
+		(instance.Spec.DisplayName != "" && instance.Spec.DisplayName != current.GetDisplayName())
+
+	// Auth0 doesn't allow updating the name or strategy
+	if changed {
+		logger.Info("updating connection", "name", instance.Spec.Name)
+
+		err = api.Connection.Update(ctx, instance.Auth0Id(), &management.Connection{
+			DisplayName:    stringOrNil(instance.Spec.DisplayName),
+			EnabledClients: &enabledClients,
+			Options:        options,
+		})
+
+		if err != nil {
+			logger.Error(err, "unable to update connection", "name", instance.Spec.Name)
+			r.Recorder.Event(instance, "Warning", EventReasonUpdateFailed, err.Error())
+			setSyncedCondition(instance, metav1.ConditionFalse, EventReasonUpdateFailed, err.Error())
+			return ctrl.Result{}, err
+		}
+	}
+
+	setSyncedCondition(instance, metav1.ConditionTrue, EventReasonUpdated, "Connection is in sync with Auth0")
+
+	return ctrl.Result{}, nil
+}
+
+// connectionsForClient maps a Client to the Connections that are enabled for it
+func (r *ConnectionReconciler) connectionsForClient(ctx context.Context, obj client.Object) []reconcile.Request {
+	connections := &auth0v1alpha1.ConnectionList{}
+	if err := r.List(ctx, connections, client.InNamespace(obj.GetNamespace())); err != nil {
+		log.FromContext(ctx).Error(err, "unable to list connections")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, c := range connections.Items {
+		if referencesClient(c.Spec.EnabledClients, obj.GetName()) {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Namespace: c.Namespace,
+					Name:      c.Name,
+				},
+			})
+		}
+	}
+
+	return requests
+}
+
+// connectionsForSecret maps a Secret to the Connections whose options it
+// holds values of
+func (r *ConnectionReconciler) connectionsForSecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	connections := &auth0v1alpha1.ConnectionList{}
+	err := r.List(
+		ctx,
+		connections,
+		client.InNamespace(obj.GetNamespace()),
+		client.MatchingFields{connectionSecretRefIndex: obj.GetName()},
+	)
+
+	if err != nil {
+		log.FromContext(ctx).Error(err, "unable to list connections")
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(connections.Items))
+	for _, c := range connections.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: c.Namespace,
+				Name:      c.Name,
+			},
+		})
+	}
+
+	return requests
+}
+
+// connectionSecretRefs returns the names of the Secrets the options of a
+// Connection are read from
+func connectionSecretRefs(instance *auth0v1alpha1.Connection) []string {
+	var names []string
+
+	if oidc := instance.Spec.OIDC; oidc != nil && oidc.ClientSecretRef != nil {
+		names = append(names, oidc.ClientSecretRef.Name)
+	}
+
+	if saml := instance.Spec.SAML; saml != nil {
+		names = append(names, saml.SigningCertRef.Name)
+	}
+
+	if azureAD := instance.Spec.AzureAD; azureAD != nil {
+		names = append(names, azureAD.ClientSecretRef.Name)
+	}
+
+	if googleWorkspace := instance.Spec.GoogleWorkspace; googleWorkspace != nil {
+		names = append(names, googleWorkspace.ClientSecretRef.Name)
+	}
+
+	if social := instance.Spec.Social; social != nil {
+		names = append(names, social.ClientSecretRef.Name)
+	}
+
+	return names
+}
+
+// connectionsForTenantSecret maps a Secret to the Connections managed in the
+// tenants whose credentials it holds
+func (r *ConnectionReconciler) connectionsForTenantSecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	return requestsForTenantSecret(ctx, r.Client, r.Tenants, &auth0v1alpha1.ConnectionList{}, obj)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ConnectionReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Index Connections by the Secrets holding their client secrets and
+	// certificates, so they can be found when they change
+	err := mgr.GetFieldIndexer().IndexField(
+		context.Background(),
+		&auth0v1alpha1.Connection{},
+		connectionSecretRefIndex,
+		func(obj client.Object) []string {
+			return connectionSecretRefs(obj.(*auth0v1alpha1.Connection))
+		},
+	)
+
+	if err != nil {
+		return err
+	}
+
+	err = indexByTenant(mgr, r.Tenants, &auth0v1alpha1.Connection{}, func(obj client.Object) *auth0v1alpha1.TenantReference {
+		return obj.(*auth0v1alpha1.Connection).Spec.TenantRef
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&auth0v1alpha1.Connection{}).
+		Watches(
+			&auth0v1alpha1.Client{},
+			handler.EnqueueRequestsFromMapFunc(r.connectionsForClient),
+		).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.connectionsForSecret),
+		).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.connectionsForTenantSecret),
+		).
+		Complete(rateLimitAware(r))
+}
@@ -0,0 +1,310 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/auth0/go-auth0/management"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	auth0v1alpha1 "github.com/rgracey/auth0-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Connection controller", func() {
+	var key types.NamespacedName
+
+	var client *auth0v1alpha1.Client
+
+	var connection *auth0v1alpha1.Connection
+
+	BeforeEach(func() {
+		suffix := time.Now().Format("20060102150405")
+		bruteForceProtection := true
+
+		key = types.NamespacedName{
+			Name:      "test-connection-" + suffix,
+			Namespace: "default",
+		}
+		client = &auth0v1alpha1.Client{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+			},
+			Spec: auth0v1alpha1.ClientSpec{
+				Name: "test-suite-client",
+				Type: "spa",
+			},
+		}
+		connection = &auth0v1alpha1.Connection{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+			},
+			Spec: auth0v1alpha1.ConnectionSpec{
+				Name:     "test-suite-" + suffix,
+				Strategy: management.ConnectionStrategyAuth0,
+				EnabledClients: []auth0v1alpha1.ClientReference{
+					{Name: client.Name},
+				},
+				Database: &auth0v1alpha1.DatabaseConnection{
+					PasswordPolicy: "good",
+					PasswordComplexityOptions: &auth0v1alpha1.PasswordComplexityOptions{
+						MinLength: 12,
+					},
+					BruteForceProtection: &bruteForceProtection,
+				},
+			},
+		}
+	})
+
+	Describe("when a connection is created", func() {
+		var auth0Connection *management.Connection
+
+		AfterEach(func() {
+			Expect(k8sClient.Delete(context.Background(), connection)).To(Succeed())
+			Expect(k8sClient.Delete(context.Background(), client)).To(Succeed())
+
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, key, &auth0v1alpha1.Connection{})
+				return ctrlclient.IgnoreNotFound(err) == nil
+			}).WithTimeout(timeout).Should(BeTrue())
+		})
+
+		JustBeforeEach(func() {
+			Expect(k8sClient.Create(ctx, client)).To(Succeed())
+			Expect(k8sClient.Create(ctx, connection)).To(Succeed())
+
+			Eventually(func() bool {
+				if err := k8sClient.Get(ctx, key, connection); err != nil {
+					return false
+				}
+				return connection.Status.Auth0Id != ""
+			}).WithTimeout(timeout).Should(BeTrue())
+
+			// Wait for the connection to be enabled for the client
+			Eventually(func() bool {
+				if err := k8sClient.Get(ctx, key, client); err != nil {
+					return false
+				}
+
+				var err error
+				auth0Connection, err = auth0Api.Connection.Read(ctx, connection.Status.Auth0Id)
+				if err != nil {
+					return false
+				}
+
+				for _, id := range auth0Connection.GetEnabledClients() {
+					if id == client.Status.Auth0Id {
+						return true
+					}
+				}
+				return false
+			}).WithTimeout(timeout).WithPolling(1 * time.Second).Should(BeTrue())
+		})
+
+		It("should create a connection in Auth0 with the correct options", func() {
+			Expect(auth0Connection.GetName()).To(Equal(connection.Spec.Name))
+			Expect(auth0Connection.GetStrategy()).To(Equal(management.ConnectionStrategyAuth0))
+
+			options, ok := auth0Connection.Options.(*management.ConnectionOptions)
+			Expect(ok).To(BeTrue())
+			Expect(options.GetPasswordPolicy()).To(Equal("good"))
+			Expect(options.GetBruteForceProtection()).To(BeTrue())
+			Expect(options.PasswordComplexityOptions).To(HaveKeyWithValue("min_length", BeNumerically("==", 12)))
+		})
+
+		It("should mark the connection as ready", func() {
+			Eventually(func() bool {
+				if err := k8sClient.Get(ctx, key, connection); err != nil {
+					return false
+				}
+				return meta.IsStatusConditionTrue(connection.Status.Conditions, auth0v1alpha1.ConditionTypeReady)
+			}).WithTimeout(timeout).Should(BeTrue())
+
+			Expect(connection.Status.PendingCreate).To(BeFalse())
+		})
+
+		It("should reuse the connection it created if its ID is lost", func() {
+			createdId := connection.Status.Auth0Id
+
+			// Lose the ID as if the status update after creating it failed
+			connection.Status.Auth0Id = ""
+			connection.Status.PendingCreate = true
+			Expect(k8sClient.Status().Update(ctx, connection)).To(Succeed())
+
+			Eventually(func() string {
+				if err := k8sClient.Get(ctx, key, connection); err != nil {
+					return ""
+				}
+				return connection.Status.Auth0Id
+			}).WithTimeout(timeout).Should(Equal(createdId))
+		})
+
+		When("a database option is removed", func() {
+			It("should revert the option in Auth0", func() {
+				connection.Spec.Database.PasswordComplexityOptions = nil
+				Expect(k8sClient.Update(ctx, connection)).To(Succeed())
+
+				Eventually(func() (map[string]interface{}, error) {
+					c, err := auth0Api.Connection.Read(ctx, connection.Status.Auth0Id)
+					if err != nil {
+						return nil, err
+					}
+					options, _ := c.Options.(*management.ConnectionOptions)
+					return options.PasswordComplexityOptions, nil
+				}).WithTimeout(timeout).WithPolling(1 * time.Second).ShouldNot(HaveKeyWithValue("min_length", BeNumerically("==", 12)))
+			})
+		})
+	})
+
+	Describe("when an oidc connection is created", func() {
+		var secret *corev1.Secret
+
+		BeforeEach(func() {
+			secret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				StringData: map[string]string{
+					"client-secret": "test-suite-oidc-client-secret",
+				},
+			}
+			Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+
+			connection.Spec.Strategy = management.ConnectionStrategyOIDC
+			connection.Spec.Database = nil
+			connection.Spec.EnabledClients = nil
+			connection.Spec.OIDC = &auth0v1alpha1.OIDCConnection{
+				ClientId: "test-suite-oidc-client",
+				ClientSecretRef: &auth0v1alpha1.SecretRef{
+					Name: secret.Name,
+					Key:  "client-secret",
+				},
+				// Use the tenant itself as the identity provider
+				DiscoveryUrl: "https://" + mustGetEnv("AUTH0_DOMAIN") + "/.well-known/openid-configuration",
+				Scopes:       []string{"openid", "profile", "email"},
+			}
+		})
+
+		AfterEach(func() {
+			Expect(k8sClient.Delete(context.Background(), connection)).To(Succeed())
+			Expect(k8sClient.Delete(context.Background(), secret)).To(Succeed())
+
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, key, &auth0v1alpha1.Connection{})
+				return ctrlclient.IgnoreNotFound(err) == nil
+			}).WithTimeout(timeout).Should(BeTrue())
+		})
+
+		It("should create a connection in Auth0 with the secret and discovered endpoints", func() {
+			Expect(k8sClient.Create(ctx, connection)).To(Succeed())
+
+			Eventually(func() bool {
+				if err := k8sClient.Get(ctx, key, connection); err != nil {
+					return false
+				}
+				return connection.Status.Auth0Id != ""
+			}).WithTimeout(timeout).Should(BeTrue())
+
+			auth0Connection, err := auth0Api.Connection.Read(ctx, connection.Status.Auth0Id)
+			Expect(err).To(BeNil())
+
+			options, ok := auth0Connection.Options.(*management.ConnectionOptionsOIDC)
+			Expect(ok).To(BeTrue())
+			Expect(options.GetClientID()).To(Equal("test-suite-oidc-client"))
+			Expect(options.GetClientSecret()).To(Equal("test-suite-oidc-client-secret"))
+			Expect(options.GetType()).To(Equal("back_channel"))
+			Expect(options.GetIssuer()).To(ContainSubstring(mustGetEnv("AUTH0_DOMAIN")))
+			Expect(options.GetTokenEndpoint()).ToNot(BeEmpty())
+		})
+
+		It("should update the connection in Auth0 when the secret changes", func() {
+			Expect(k8sClient.Create(ctx, connection)).To(Succeed())
+
+			Eventually(func() (string, error) {
+				err := k8sClient.Get(ctx, key, connection)
+				return connection.Status.Auth0Id, err
+			}).WithTimeout(timeout).ShouldNot(BeEmpty())
+
+			Expect(k8sClient.Get(ctx, key, secret)).To(Succeed())
+			secret.StringData = map[string]string{
+				"client-secret": "test-suite-oidc-client-secret-rotated",
+			}
+			Expect(k8sClient.Update(ctx, secret)).To(Succeed())
+
+			Eventually(func() (string, error) {
+				c, err := auth0Api.Connection.Read(ctx, connection.Status.Auth0Id)
+				if err != nil {
+					return "", err
+				}
+				options, _ := c.Options.(*management.ConnectionOptionsOIDC)
+				return options.GetClientSecret(), nil
+			}).WithTimeout(timeout).WithPolling(1 * time.Second).Should(Equal("test-suite-oidc-client-secret-rotated"))
+		})
+	})
+
+	Describe("when a social connection is created", func() {
+		var secret *corev1.Secret
+
+		BeforeEach(func() {
+			secret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				StringData: map[string]string{
+					"client-secret": "test-suite-github-client-secret",
+				},
+			}
+			Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+
+			connection.Spec.Strategy = management.ConnectionStrategyGitHub
+			connection.Spec.Database = nil
+			connection.Spec.EnabledClients = nil
+			connection.Spec.Social = &auth0v1alpha1.SocialConnection{
+				ClientId: "test-suite-github-client",
+				ClientSecretRef: auth0v1alpha1.SecretRef{
+					Name: secret.Name,
+					Key:  "client-secret",
+				},
+				Scopes: []string{"email", "read_user"},
+			}
+		})
+
+		AfterEach(func() {
+			Expect(k8sClient.Delete(context.Background(), connection)).To(Succeed())
+			Expect(k8sClient.Delete(context.Background(), secret)).To(Succeed())
+
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, key, &auth0v1alpha1.Connection{})
+				return ctrlclient.IgnoreNotFound(err) == nil
+			}).WithTimeout(timeout).Should(BeTrue())
+		})
+
+		It("should create a connection in Auth0 with the app keys and scopes", func() {
+			Expect(k8sClient.Create(ctx, connection)).To(Succeed())
+
+			Eventually(func() bool {
+				if err := k8sClient.Get(ctx, key, connection); err != nil {
+					return false
+				}
+				return connection.Status.Auth0Id != ""
+			}).WithTimeout(timeout).Should(BeTrue())
+
+			auth0Connection, err := auth0Api.Connection.Read(ctx, connection.Status.Auth0Id)
+			Expect(err).To(BeNil())
+
+			options, ok := auth0Connection.Options.(*management.ConnectionOptionsGitHub)
+			Expect(ok).To(BeTrue())
+			Expect(options.GetClientID()).To(Equal("test-suite-github-client"))
+			Expect(options.GetClientSecret()).To(Equal("test-suite-github-client-secret"))
+			Expect(options.Scopes()).To(ConsistOf("email", "read_user"))
+		})
+	})
+})
@@ -0,0 +1,483 @@
+package controller
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/auth0/go-auth0"
+	"github.com/auth0/go-auth0/management"
+	auth0v1alpha1 "github.com/rgracey/auth0-operator/api/v1alpha1"
+)
+
+// connectionOptions builds the Auth0 options for a Connection. Options that
+// aren't managed by the Connection are carried over from current, which is
+// nil if the connection hasn't been created yet
+func (r *ConnectionReconciler) connectionOptions(
+	ctx context.Context,
+	instance *auth0v1alpha1.Connection,
+	current interface{},
+) (interface{}, error) {
+	switch instance.Spec.Strategy {
+	case management.ConnectionStrategyAuth0:
+		opts, _ := current.(*management.ConnectionOptions)
+		if opts == nil {
+			opts = &management.ConnectionOptions{}
+		}
+
+		return databaseConnectionOptions(instance.Spec.Database, opts), nil
+
+	case management.ConnectionStrategyOIDC:
+		opts, _ := current.(*management.ConnectionOptionsOIDC)
+		if opts == nil {
+			opts = &management.ConnectionOptionsOIDC{}
+		}
+
+		return r.oidcConnectionOptions(ctx, instance.Namespace, instance.Spec.OIDC, opts)
+
+	case management.ConnectionStrategySAML:
+		opts, _ := current.(*management.ConnectionOptionsSAML)
+		if opts == nil {
+			opts = &management.ConnectionOptionsSAML{}
+		}
+
+		return r.samlConnectionOptions(ctx, instance.Namespace, instance.Spec.SAML, opts)
+
+	case management.ConnectionStrategyAzureAD:
+		opts, _ := current.(*management.ConnectionOptionsAzureAD)
+		if opts == nil {
+			opts = &management.ConnectionOptionsAzureAD{}
+		}
+
+		return r.azureADConnectionOptions(ctx, instance.Namespace, instance.Spec.AzureAD, opts)
+
+	case management.ConnectionStrategyGoogleApps:
+		opts, _ := current.(*management.ConnectionOptionsGoogleApps)
+		if opts == nil {
+			opts = &management.ConnectionOptionsGoogleApps{}
+		}
+
+		return r.googleWorkspaceConnectionOptions(ctx, instance.Namespace, instance.Spec.GoogleWorkspace, opts)
+
+	case management.ConnectionStrategyGoogleOAuth2,
+		management.ConnectionStrategyGitHub,
+		management.ConnectionStrategyApple,
+		management.ConnectionStrategyWindowsLive:
+		return r.socialConnectionOptions(ctx, instance, current)
+	}
+
+	return nil, fmt.Errorf("unsupported connection strategy \"%s\"", instance.Spec.Strategy)
+}
+
+// databaseConnectionOptions applies the options of an auth0 (database)
+// connection to opts. Auth0 replaces the options as a whole, so options
+// that aren't set are cleared from opts to revert them to Auth0's defaults.
+// The options are left as they are if database isn't set at all
+func databaseConnectionOptions(
+	database *auth0v1alpha1.DatabaseConnection,
+	opts *management.ConnectionOptions,
+) *management.ConnectionOptions {
+	if database == nil {
+		return opts
+	}
+
+	opts.PasswordPolicy = stringOrNil(database.PasswordPolicy)
+	opts.RequiresUsername = database.RequiresUsername
+	opts.DisableSignup = database.DisableSignup
+	opts.BruteForceProtection = database.BruteForceProtection
+
+	opts.PasswordComplexityOptions = nil
+	if database.PasswordComplexityOptions != nil {
+		opts.PasswordComplexityOptions = map[string]interface{}{
+			"min_length": database.PasswordComplexityOptions.MinLength,
+		}
+	}
+
+	opts.PasswordHistory = nil
+	if database.PasswordHistory != nil {
+		opts.PasswordHistory = map[string]interface{}{
+			"enable": database.PasswordHistory.Enable,
+			"size":   database.PasswordHistory.Size,
+		}
+	}
+
+	opts.PasswordDictionary = nil
+	if database.PasswordDictionary != nil {
+		dictionary := database.PasswordDictionary.Dictionary
+		if dictionary == nil {
+			dictionary = []string{}
+		}
+
+		opts.PasswordDictionary = map[string]interface{}{
+			"enable":     database.PasswordDictionary.Enable,
+			"dictionary": dictionary,
+		}
+	}
+
+	opts.PasswordNoPersonalInfo = nil
+	if database.PasswordNoPersonalInfo != nil {
+		opts.PasswordNoPersonalInfo = map[string]interface{}{
+			"enable": database.PasswordNoPersonalInfo.Enable,
+		}
+	}
+
+	opts.Validation = nil
+	if database.UsernameLength != nil {
+		opts.Validation = map[string]interface{}{
+			"username": map[string]interface{}{
+				"min": database.UsernameLength.Min,
+				"max": database.UsernameLength.Max,
+			},
+		}
+	}
+
+	return opts
+}
+
+// oidcConnectionOptions applies the options of an oidc connection to opts
+func (r *ConnectionReconciler) oidcConnectionOptions(
+	ctx context.Context,
+	namespace string,
+	oidc *auth0v1alpha1.OIDCConnection,
+	opts *management.ConnectionOptionsOIDC,
+) (*management.ConnectionOptionsOIDC, error) {
+	if oidc.ClientSecretRef != nil {
+		clientSecret, err := loadSecretValue(ctx, r.Client, namespace, *oidc.ClientSecretRef)
+
+		if err != nil {
+			return nil, fmt.Errorf("oidc clientSecretRef: %w", err)
+		}
+
+		opts.ClientSecret = &clientSecret
+	}
+
+	opts.ClientID = &oidc.ClientId
+	opts.DiscoveryURL = stringOrNil(oidc.DiscoveryUrl)
+	opts.DomainAliases = stringSliceOrNil(oidc.DomainAliases)
+	opts.LogoURL = stringOrNil(oidc.LogoUrl)
+
+	if len(oidc.Scopes) > 0 {
+		scope := strings.Join(oidc.Scopes, " ")
+		opts.Scope = &scope
+	}
+
+	switch {
+	case oidc.Type != "":
+		opts.Type = &oidc.Type
+	case opts.ClientSecret != nil:
+		opts.Type = auth0.String("back_channel")
+	default:
+		opts.Type = auth0.String("front_channel")
+	}
+
+	if oidc.Issuer != "" {
+		opts.Issuer = &oidc.Issuer
+	}
+
+	if oidc.AuthorizationEndpoint != "" {
+		opts.AuthorizationEndpoint = &oidc.AuthorizationEndpoint
+	}
+
+	if oidc.TokenEndpoint != "" {
+		opts.TokenEndpoint = &oidc.TokenEndpoint
+	}
+
+	if oidc.UserInfoEndpoint != "" {
+		opts.UserInfoEndpoint = &oidc.UserInfoEndpoint
+	}
+
+	if oidc.JwksUri != "" {
+		opts.JWKSURI = &oidc.JwksUri
+	}
+
+	// Auth0 requires the endpoints to be set, so any that aren't are
+	// looked up from the discovery document
+	if oidc.DiscoveryUrl != "" && (opts.Issuer == nil ||
+		opts.AuthorizationEndpoint == nil ||
+		opts.TokenEndpoint == nil ||
+		opts.UserInfoEndpoint == nil ||
+		opts.JWKSURI == nil) {
+		discovery, err := fetchOIDCDiscovery(ctx, oidc.DiscoveryUrl)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if opts.Issuer == nil {
+			opts.Issuer = stringOrNil(discovery.Issuer)
+		}
+
+		if opts.AuthorizationEndpoint == nil {
+			opts.AuthorizationEndpoint = stringOrNil(discovery.AuthorizationEndpoint)
+		}
+
+		if opts.TokenEndpoint == nil {
+			opts.TokenEndpoint = stringOrNil(discovery.TokenEndpoint)
+		}
+
+		if opts.UserInfoEndpoint == nil {
+			opts.UserInfoEndpoint = stringOrNil(discovery.UserInfoEndpoint)
+		}
+
+		if opts.JWKSURI == nil {
+			opts.JWKSURI = stringOrNil(discovery.JwksUri)
+		}
+	}
+
+	return opts, nil
+}
+
+// oidcDiscovery is the subset of an OpenID Connect discovery document
+// used to configure oidc connections
+type oidcDiscovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	UserInfoEndpoint      string `json:"userinfo_endpoint"`
+	JwksUri               string `json:"jwks_uri"`
+}
+
+// discoveryClient is used to fetch OpenID Connect discovery documents, so
+// an unresponsive identity provider can't block reconciling indefinitely
+var discoveryClient = &http.Client{Timeout: 10 * time.Second}
+
+// fetchOIDCDiscovery fetches the OpenID Connect discovery document at url
+func fetchOIDCDiscovery(ctx context.Context, url string) (*oidcDiscovery, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := discoveryClient.Do(req)
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch oidc discovery document: %w", err)
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf(
+			"unable to fetch oidc discovery document \"%s\": %s",
+			url,
+			res.Status,
+		)
+	}
+
+	discovery := &oidcDiscovery{}
+	if err := json.NewDecoder(res.Body).Decode(discovery); err != nil {
+		return nil, fmt.Errorf("unable to decode oidc discovery document: %w", err)
+	}
+
+	return discovery, nil
+}
+
+// samlConnectionOptions applies the options of a samlp connection to opts
+func (r *ConnectionReconciler) samlConnectionOptions(
+	ctx context.Context,
+	namespace string,
+	saml *auth0v1alpha1.SAMLConnection,
+	opts *management.ConnectionOptionsSAML,
+) (*management.ConnectionOptionsSAML, error) {
+	signingCert, err := loadSecretValue(ctx, r.Client, namespace, saml.SigningCertRef)
+
+	if err != nil {
+		return nil, fmt.Errorf("saml signingCertRef: %w", err)
+	}
+
+	// Auth0 expects the PEM encoded certificate to be base64 encoded
+	encodedSigningCert := base64.StdEncoding.EncodeToString([]byte(signingCert))
+	opts.SigningCert = &encodedSigningCert
+
+	opts.SignInEndpoint = stringOrNil(saml.SignInEndpoint)
+	opts.SignOutEndpoint = stringOrNil(saml.SignOutEndpoint)
+	opts.DisableSignOut = saml.DisableSignOut
+	opts.SignSAMLRequest = saml.SignSAMLRequest
+	opts.SignatureAlgorithm = stringOrNil(saml.SignatureAlgorithm)
+	opts.DigestAglorithm = stringOrNil(saml.DigestAlgorithm)
+	opts.ProtocolBinding = stringOrNil(saml.ProtocolBinding)
+	opts.UserIDAttribute = stringOrNil(saml.UserIdAttribute)
+	opts.DomainAliases = stringSliceOrNil(saml.DomainAliases)
+	opts.LogoURL = stringOrNil(saml.LogoUrl)
+
+	if saml.FieldsMap != nil {
+		opts.FieldsMap = map[string]interface{}{}
+
+		for k, v := range saml.FieldsMap {
+			opts.FieldsMap[k] = v
+		}
+	}
+
+	return opts, nil
+}
+
+// azureADConnectionOptions applies the options of a waad connection to opts
+func (r *ConnectionReconciler) azureADConnectionOptions(
+	ctx context.Context,
+	namespace string,
+	azureAD *auth0v1alpha1.AzureADConnection,
+	opts *management.ConnectionOptionsAzureAD,
+) (*management.ConnectionOptionsAzureAD, error) {
+	clientSecret, err := loadSecretValue(ctx, r.Client, namespace, azureAD.ClientSecretRef)
+
+	if err != nil {
+		return nil, fmt.Errorf("azureAd clientSecretRef: %w", err)
+	}
+
+	opts.ClientID = &azureAD.ClientId
+	opts.ClientSecret = &clientSecret
+	opts.Domain = &azureAD.Domain
+	opts.TenantDomain = &azureAD.Domain
+	opts.WAADProtocol = stringOrNil(azureAD.Protocol)
+	opts.IdentityAPI = stringOrNil(azureAD.IdentityApi)
+	opts.UseCommonEndpoint = azureAD.UseCommonEndpoint
+	opts.BasicProfile = azureAD.BasicProfile
+	opts.ExtendedProfile = azureAD.ExtendedProfile
+	opts.Groups = azureAD.Groups
+	opts.EnableUsersAPI = azureAD.EnableUsersApi
+	opts.DomainAliases = stringSliceOrNil(azureAD.DomainAliases)
+	opts.LogoURL = stringOrNil(azureAD.LogoUrl)
+
+	return opts, nil
+}
+
+// googleWorkspaceConnectionOptions applies the options of a google-apps
+// connection to opts
+func (r *ConnectionReconciler) googleWorkspaceConnectionOptions(
+	ctx context.Context,
+	namespace string,
+	googleWorkspace *auth0v1alpha1.GoogleWorkspaceConnection,
+	opts *management.ConnectionOptionsGoogleApps,
+) (*management.ConnectionOptionsGoogleApps, error) {
+	clientSecret, err := loadSecretValue(ctx, r.Client, namespace, googleWorkspace.ClientSecretRef)
+
+	if err != nil {
+		return nil, fmt.Errorf("googleWorkspace clientSecretRef: %w", err)
+	}
+
+	opts.ClientID = &googleWorkspace.ClientId
+	opts.ClientSecret = &clientSecret
+	opts.Domain = &googleWorkspace.Domain
+	opts.TenantDomain = &googleWorkspace.Domain
+	opts.BasicProfile = googleWorkspace.BasicProfile
+	opts.ExtendedProfile = googleWorkspace.ExtendedProfile
+	opts.Groups = googleWorkspace.Groups
+	opts.EnableUsersAPI = googleWorkspace.EnableUsersApi
+	opts.DomainAliases = stringSliceOrNil(googleWorkspace.DomainAliases)
+	opts.LogoURL = stringOrNil(googleWorkspace.LogoUrl)
+
+	return opts, nil
+}
+
+// scopedConnectionOptions is implemented by the options of connections whose
+// scopes are toggled individually
+type scopedConnectionOptions interface {
+	Scopes() []string
+	SetScopes(enable bool, scopes ...string)
+}
+
+// socialConnectionOptions builds the options of a google-oauth2, github,
+// apple or windowslive connection on top of current
+func (r *ConnectionReconciler) socialConnectionOptions(
+	ctx context.Context,
+	instance *auth0v1alpha1.Connection,
+	current interface{},
+) (interface{}, error) {
+	social := instance.Spec.Social
+
+	clientSecret, err := loadSecretValue(ctx, r.Client, instance.Namespace, social.ClientSecretRef)
+
+	if err != nil {
+		return nil, fmt.Errorf("social clientSecretRef: %w", err)
+	}
+
+	var opts scopedConnectionOptions
+
+	switch instance.Spec.Strategy {
+	case management.ConnectionStrategyGoogleOAuth2:
+		google, _ := current.(*management.ConnectionOptionsGoogleOAuth2)
+		if google == nil {
+			google = &management.ConnectionOptionsGoogleOAuth2{}
+		}
+
+		google.ClientID = &social.ClientId
+		google.ClientSecret = &clientSecret
+		google.SetUserAttributes = stringOrNil(social.SetUserRootAttributes)
+		google.NonPersistentAttrs = stringSliceOrNil(social.NonPersistentAttributes)
+		opts = google
+
+	case management.ConnectionStrategyGitHub:
+		github, _ := current.(*management.ConnectionOptionsGitHub)
+		if github == nil {
+			github = &management.ConnectionOptionsGitHub{}
+		}
+
+		github.ClientID = &social.ClientId
+		github.ClientSecret = &clientSecret
+		github.SetUserAttributes = stringOrNil(social.SetUserRootAttributes)
+		github.NonPersistentAttrs = stringSliceOrNil(social.NonPersistentAttributes)
+		opts = github
+
+	case management.ConnectionStrategyApple:
+		apple, _ := current.(*management.ConnectionOptionsApple)
+		if apple == nil {
+			apple = &management.ConnectionOptionsApple{}
+		}
+
+		apple.ClientID = &social.ClientId
+		apple.ClientSecret = &clientSecret
+		apple.TeamID = &social.TeamId
+		apple.KeyID = &social.KeyId
+		apple.SetUserAttributes = stringOrNil(social.SetUserRootAttributes)
+		apple.NonPersistentAttrs = stringSliceOrNil(social.NonPersistentAttributes)
+		opts = apple
+
+	case management.ConnectionStrategyWindowsLive:
+		windowsLive, _ := current.(*management.ConnectionOptionsWindowsLive)
+		if windowsLive == nil {
+			windowsLive = &management.ConnectionOptionsWindowsLive{}
+		}
+
+		windowsLive.ClientID = &social.ClientId
+		windowsLive.ClientSecret = &clientSecret
+		windowsLive.SetUserAttributes = stringOrNil(social.SetUserRootAttributes)
+		windowsLive.NonPersistentAttrs = stringSliceOrNil(social.NonPersistentAttributes)
+		opts = windowsLive
+	}
+
+	if err := setConnectionScopes(opts, social.Scopes); err != nil {
+		return nil, err
+	}
+
+	return opts, nil
+}
+
+// setConnectionScopes enables the given scopes on opts and disables all
+// others. An error is returned if a scope isn't supported by the connection
+func setConnectionScopes(opts scopedConnectionOptions, scopes []string) error {
+	opts.SetScopes(false, opts.Scopes()...)
+	opts.SetScopes(true, scopes...)
+
+	enabled := map[string]bool{}
+	for _, s := range opts.Scopes() {
+		enabled[s] = true
+	}
+
+	var unsupported []string
+	for _, s := range scopes {
+		if !enabled[s] {
+			unsupported = append(unsupported, s)
+		}
+	}
+
+	if len(unsupported) > 0 {
+		return fmt.Errorf("unsupported scopes: %s", strings.Join(unsupported, ", "))
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/auth0/go-auth0/management"
+	auth0v1alpha1 "github.com/rgracey/auth0-operator/api/v1alpha1"
+)
+
+const (
+	finalizerName = "finalizer.auth0.gracey.io"
+)
+
+// hasFinalizer returns true if the object has the finalizer. False otherwise
+func hasFinalizer(instance client.Object) bool {
+	return controllerutil.ContainsFinalizer(instance, finalizerName)
+}
+
+// addFinalizer adds the finalizer to the object if it doesn't already exist
+func addFinalizer(ctx context.Context, c client.Client, instance client.Object) error {
+	controllerutil.AddFinalizer(instance, finalizerName)
+	return c.Update(ctx, instance)
+}
+
+// removeFinalizer removes the finalizer from the object if it exists
+func removeFinalizer(ctx context.Context, c client.Client, instance client.Object) error {
+	if !hasFinalizer(instance) {
+		return nil
+	}
+
+	controllerutil.RemoveFinalizer(instance, finalizerName)
+	return c.Update(ctx, instance)
+}
+
+// deletionApi returns the management API client of the tenant that an object
+// being deleted is managed in. Deletion mustn't be blocked by a tenant that
+// can no longer be used, e.g. because it was deleted along with the
+// namespace, so in that case a Warning event is recorded and nil is
+// returned, leaving the Auth0 resource in place. Other errors are returned
+// so that deletion is retried
+func deletionApi(
+	ctx context.Context,
+	tenants *Tenants,
+	recorder record.EventRecorder,
+	instance client.Object,
+	ref *auth0v1alpha1.TenantReference,
+) (*management.Management, error) {
+	api, err := tenants.Api(ctx, instance.GetNamespace(), ref)
+
+	if err != nil && !isTenantUnusable(err) {
+		return nil, err
+	}
+
+	if err != nil {
+		log.FromContext(ctx).Error(err, "unable to use Auth0 tenant, leaving resource in Auth0")
+		recorder.Event(
+			instance,
+			"Warning",
+			EventReasonOrphaned,
+			fmt.Sprintf("Left resource in Auth0 as its tenant can't be used: %s", err),
+		)
+
+		return nil, nil
+	}
+
+	return api, nil
+}
@@ -0,0 +1,407 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/auth0/go-auth0/management"
+	auth0v1alpha1 "github.com/rgracey/auth0-operator/api/v1alpha1"
+)
+
+// OrganizationReconciler reconciles a Organization object
+type OrganizationReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	Tenants  *Tenants
+}
+
+//+kubebuilder:rbac:groups=auth0.gracey.io,resources=organizations,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=auth0.gracey.io,resources=organizations/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=auth0.gracey.io,resources=organizations/finalizers,verbs=update
+
+// Reconcile moves the Auth0 organization towards the state specified by
+// the Organization object
+func (r *OrganizationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	// Fetch the Organization instance
+	instance := &auth0v1alpha1.Organization{}
+	if err := r.Get(ctx, req.NamespacedName, instance); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	// Check if the Organization instance is being deleted, and run finalizer logic.
+	// This comes before resolving the tenant so that deletion isn't blocked
+	// by a tenant that can no longer be used
+	if instance.IsBeingDeleted() {
+		logger.Info("deleting organization", "name", instance.Spec.Name)
+		return ctrl.Result{}, r.handleFinalizer(ctx, instance)
+	}
+
+	// The Organization is managed in the Auth0 tenant it references. A finalizer
+	// isn't added until the tenant can be used to clean up after it
+	api, err := r.Tenants.Api(ctx, instance.Namespace, instance.Spec.TenantRef)
+
+	if err != nil {
+		logger.Error(err, "unable to get Auth0 tenant")
+		setSyncedCondition(instance, metav1.ConditionFalse, ConditionReasonTenantUnavailable, err.Error())
+
+		if statusErr := updateSyncedStatus(ctx, r.Client, instance, "Organization"); statusErr != nil {
+			logger.Error(statusErr, "unable to update organization status", "name", instance.Spec.Name)
+		}
+
+		return ctrl.Result{}, err
+	}
+
+	if !hasFinalizer(instance) {
+		return ctrl.Result{Requeue: true}, addFinalizer(ctx, r.Client, instance)
+	}
+
+	result, err := r.reconcileOrganization(ctx, api, instance)
+
+	if statusErr := updateSyncedStatus(ctx, r.Client, instance, "Organization"); statusErr != nil {
+		logger.Error(statusErr, "unable to update organization status", "name", instance.Spec.Name)
+
+		if err == nil {
+			err = statusErr
+		}
+	}
+
+	return result, err
+}
+
+// reconcileOrganization moves the Auth0 organization and its connections
+// towards the state specified by the Organization, recording the outcome in
+// its Synced condition
+func (r *OrganizationReconciler) reconcileOrganization(
+	ctx context.Context,
+	api *management.Management,
+	instance *auth0v1alpha1.Organization,
+) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	// Reuse an organization created by a previous reconcile whose status
+	// update failed. Organization names are unique in a tenant
+	if instance.Auth0Id() == "" && instance.Status.PendingCreate {
+		existing, err := api.Organization.ReadByName(ctx, instance.Spec.Name)
+
+		if err != nil && !isNotFound(err) {
+			logger.Error(err, "unable to search for existing organization", "name", instance.Spec.Name)
+			r.Recorder.Event(instance, "Warning", EventReasonCreateFailed, err.Error())
+			setSyncedCondition(instance, metav1.ConditionFalse, EventReasonCreateFailed, err.Error())
+			return ctrl.Result{}, err
+		}
+
+		if err == nil {
+			logger.Info("found existing organization", "name", instance.Spec.Name, "Auth0 id", existing.GetID())
+			instance.Status.Auth0Id = existing.GetID()
+		}
+
+		instance.Status.PendingCreate = false
+	}
+
+	// Create the Organization if it doesn't exist
+	if instance.Auth0Id() == "" {
+		o := toAuth0Organization(instance)
+
+		// Record that a create is pending first, so the organization is
+		// looked up if its ID can't be recorded after creating it
+		instance.Status.PendingCreate = true
+
+		if err := r.Status().Update(ctx, instance); err != nil {
+			logger.Error(err, "unable to update organization status", "name", instance.Spec.Name)
+			setSyncedCondition(instance, metav1.ConditionFalse, EventReasonCreateFailed, err.Error())
+			return ctrl.Result{}, err
+		}
+
+		logger.Info("creating organization", "name", instance.Spec.Name)
+		err := api.Organization.Create(ctx, o)
+
+		if err != nil {
+			logger.Error(err, "unable to create organization", "name", instance.Spec.Name)
+			r.Recorder.Event(instance, "Warning", EventReasonCreateFailed, err.Error())
+			setSyncedCondition(instance, metav1.ConditionFalse, EventReasonCreateFailed, err.Error())
+
+			// Auth0 rejected the request, so no organization was created
+			if isClientError(err) {
+				instance.Status.PendingCreate = false
+			}
+
+			return ctrl.Result{}, err
+		}
+
+		logger.Info("created organization", "name", instance.Spec.Name, "Auth0 id", o.GetID())
+
+		// If this fails the organization is looked up next time
+		instance.Status.Auth0Id = o.GetID()
+		instance.Status.PendingCreate = false
+		apiErr := r.Status().Update(ctx, instance)
+
+		if apiErr != nil {
+			logger.Error(apiErr, "unable to update organization status", "name", instance.Spec.Name)
+			instance.Status.Auth0Id = ""
+			instance.Status.PendingCreate = true
+			setSyncedCondition(instance, metav1.ConditionFalse, EventReasonCreateFailed, apiErr.Error())
+			return ctrl.Result{}, apiErr
+		}
+
+		r.Recorder.Event(
+			instance,
+			"Normal",
+			EventReasonCreated,
+			fmt.Sprintf(
+				"Created organization %s (ID: %s)",
+				instance.Spec.Name,
+				instance.Status.Auth0Id,
+			),
+		)
+	} else {
+		current, err := api.Organization.Read(ctx, instance.Auth0Id())
+
+		if err != nil {
+			logger.Error(err, "unable to fetch organization", "name", instance.Spec.Name)
+			r.Recorder.Event(instance, "Warning", EventReasonUpdateFailed, err.Error())
+			setSyncedCondition(instance, metav1.ConditionFalse, EventReasonUpdateFailed, err.Error())
+			return ctrl.Result{}, err
+		}
+
+		o := toAuth0Organization(instance)
+
+		// Metadata is replaced as a whole, so it's cleared when removed
+		if o.Metadata == nil {
+			o.Metadata = &map[string]string{}
+		}
+
+		// Keys removed from the metadata are only removed by an update, so
+		// the metadata must match exactly
+		if !reflect.DeepEqual(*o.Metadata, current.GetMetadata()) || needsUpdate(o, current) {
+			logger.Info("updating organization", "name", instance.Spec.Name)
+
+			if err := api.Organization.Update(ctx, instance.Auth0Id(), o); err != nil {
+				logger.Error(err, "unable to update organization", "name", instance.Spec.Name)
+				r.Recorder.Event(instance, "Warning", EventReasonUpdateFailed, err.Error())
+				setSyncedCondition(instance, metav1.ConditionFalse, EventReasonUpdateFailed, err.Error())
+				return ctrl.Result{}, err
+			}
+		}
+	}
+
+	if err := r.syncConnections(ctx, api, instance); err != nil {
+		logger.Error(err, "unable to update organization connections", "name", instance.Spec.Name)
+		r.Recorder.Event(instance, "Warning", EventReasonUpdateFailed, err.Error())
+		setSyncedCondition(instance, metav1.ConditionFalse, EventReasonUpdateFailed, err.Error())
+		return ctrl.Result{}, err
+	}
+
+	setSyncedCondition(instance, metav1.ConditionTrue, EventReasonUpdated, "Organization is in sync with Auth0")
+
+	return ctrl.Result{}, nil
+}
+
+// toAuth0Organization converts an Organization to an Auth0 organization
+func toAuth0Organization(instance *auth0v1alpha1.Organization) *management.Organization {
+	o := &management.Organization{
+		Name:        &instance.Spec.Name,
+		DisplayName: stringOrNil(instance.Spec.DisplayName),
+	}
+
+	if len(instance.Spec.Metadata) > 0 {
+		o.Metadata = &instance.Spec.Metadata
+	}
+
+	if branding := instance.Spec.Branding; branding != nil {
+		o.Branding = &management.OrganizationBranding{
+			LogoURL: stringOrNil(branding.LogoUrl),
+		}
+
+		if branding.Colors != nil {
+			o.Branding.Colors = &map[string]string{
+				"primary":         branding.Colors.Primary,
+				"page_background": branding.Colors.PageBackground,
+			}
+		}
+	}
+
+	return o
+}
+
+// syncConnections enables the connections of the Organization that aren't
+// enabled in Auth0, updates those whose settings differ and disables those
+// that are no longer declared. Connections that haven't been created in
+// Auth0 yet are skipped
+func (r *OrganizationReconciler) syncConnections(ctx context.Context, api *management.Management, instance *auth0v1alpha1.Organization) error {
+	desired := map[string]bool{}
+
+	for _, c := range instance.Spec.EnabledConnections {
+		id, err := resolveConnectionReference(
+			ctx,
+			r.Client,
+			r.Tenants,
+			instance.Namespace,
+			instance.Spec.TenantRef,
+			c.ConnectionRef,
+		)
+
+		if err != nil {
+			return err
+		}
+
+		if id != "" {
+			desired[id] = c.AssignMembershipOnLogin
+		}
+	}
+
+	current, err := r.listConnections(ctx, api, instance.Auth0Id())
+
+	if err != nil {
+		return err
+	}
+
+	for _, c := range current {
+		assignMembershipOnLogin, ok := desired[c.GetConnectionID()]
+
+		switch {
+		case !ok:
+			err = api.Organization.DeleteConnection(ctx, instance.Auth0Id(), c.GetConnectionID())
+		case assignMembershipOnLogin != c.GetAssignMembershipOnLogin():
+			err = api.Organization.UpdateConnection(
+				ctx,
+				instance.Auth0Id(),
+				c.GetConnectionID(),
+				&management.OrganizationConnection{
+					AssignMembershipOnLogin: &assignMembershipOnLogin,
+				},
+			)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		delete(desired, c.GetConnectionID())
+	}
+
+	for id, assignMembershipOnLogin := range desired {
+		connectionID, assign := id, assignMembershipOnLogin
+
+		err = api.Organization.AddConnection(ctx, instance.Auth0Id(), &management.OrganizationConnection{
+			ConnectionID:            &connectionID,
+			AssignMembershipOnLogin: &assign,
+		})
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// listConnections returns all enabled connections of the organization with
+// the given ID
+func (r *OrganizationReconciler) listConnections(
+	ctx context.Context,
+	api *management.Management,
+	id string,
+) ([]*management.OrganizationConnection, error) {
+	var connections []*management.OrganizationConnection
+
+	for page := 0; ; page++ {
+		list, err := api.Organization.Connections(ctx, id, management.Page(page))
+
+		if err != nil {
+			return nil, err
+		}
+
+		connections = append(connections, list.OrganizationConnections...)
+
+		if !list.HasNext() {
+			return connections, nil
+		}
+	}
+}
+
+// organizationsForConnection maps a Connection to the Organizations that
+// it's enabled for
+func (r *OrganizationReconciler) organizationsForConnection(ctx context.Context, obj client.Object) []reconcile.Request {
+	organizations := &auth0v1alpha1.OrganizationList{}
+	if err := r.List(ctx, organizations, client.InNamespace(obj.GetNamespace())); err != nil {
+		log.FromContext(ctx).Error(err, "unable to list organizations")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, o := range organizations.Items {
+		for _, c := range o.Spec.EnabledConnections {
+			if c.ConnectionRef.Name == obj.GetName() {
+				requests = append(requests, reconcile.Request{
+					NamespacedName: types.NamespacedName{
+						Namespace: o.Namespace,
+						Name:      o.Name,
+					},
+				})
+				break
+			}
+		}
+	}
+
+	return requests
+}
+
+// organizationsForTenantSecret maps a Secret to the Organizations managed in
+// the tenants whose credentials it holds
+func (r *OrganizationReconciler) organizationsForTenantSecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	return requestsForTenantSecret(ctx, r.Client, r.Tenants, &auth0v1alpha1.OrganizationList{}, obj)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *OrganizationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	err := indexByTenant(mgr, r.Tenants, &auth0v1alpha1.Organization{}, func(obj client.Object) *auth0v1alpha1.TenantReference {
+		return obj.(*auth0v1alpha1.Organization).Spec.TenantRef
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&auth0v1alpha1.Organization{}).
+		Watches(
+			&auth0v1alpha1.Connection{},
+			handler.EnqueueRequestsFromMapFunc(r.organizationsForConnection),
+		).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.organizationsForTenantSecret),
+		).
+		Complete(rateLimitAware(r))
+}
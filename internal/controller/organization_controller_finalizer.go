@@ -0,0 +1,51 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	auth0v1alpha1 "github.com/rgracey/auth0-operator/api/v1alpha1"
+)
+
+// handleFinalizer handles the finalizer logic for the Organization
+func (r *OrganizationReconciler) handleFinalizer(
+	ctx context.Context,
+	instance *auth0v1alpha1.Organization,
+) error {
+	if !hasFinalizer(instance) {
+		return nil
+	}
+
+	if instance.Auth0Id() == "" {
+		return removeFinalizer(ctx, r.Client, instance)
+	}
+
+	api, err := deletionApi(ctx, r.Tenants, r.Recorder, instance, instance.Spec.TenantRef)
+	if err != nil {
+		return err
+	}
+
+	if api == nil {
+		return removeFinalizer(ctx, r.Client, instance)
+	}
+
+	err = api.Organization.Delete(ctx, instance.Status.Auth0Id)
+
+	if err != nil {
+		r.Recorder.Event(instance, "Warning", EventReasonDeleteFailed, err.Error())
+		return err
+	}
+
+	r.Recorder.Event(
+		instance,
+		"Normal",
+		EventReasonDeleted,
+		fmt.Sprintf(
+			"Deleted organization %s (ID: %s)",
+			instance.Spec.Name,
+			instance.Status.Auth0Id,
+		),
+	)
+
+	return removeFinalizer(ctx, r.Client, instance)
+}
@@ -0,0 +1,159 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/auth0/go-auth0/management"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	auth0v1alpha1 "github.com/rgracey/auth0-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Organization controller", func() {
+	var key types.NamespacedName
+
+	var connection *auth0v1alpha1.Connection
+
+	var organization *auth0v1alpha1.Organization
+
+	BeforeEach(func() {
+		suffix := time.Now().Format("20060102150405")
+
+		key = types.NamespacedName{
+			Name:      "test-organization-" + suffix,
+			Namespace: "default",
+		}
+		connection = &auth0v1alpha1.Connection{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+			},
+			Spec: auth0v1alpha1.ConnectionSpec{
+				Name:     "test-suite-org-" + suffix,
+				Strategy: management.ConnectionStrategyAuth0,
+			},
+		}
+		organization = &auth0v1alpha1.Organization{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+			},
+			Spec: auth0v1alpha1.OrganizationSpec{
+				Name:        "test-suite-" + suffix,
+				DisplayName: "Test Suite",
+				Branding: &auth0v1alpha1.OrganizationBranding{
+					Colors: &auth0v1alpha1.OrganizationBrandingColors{
+						Primary:        "#0059d6",
+						PageBackground: "#000000",
+					},
+				},
+				Metadata: map[string]string{
+					"plan": "test",
+				},
+				EnabledConnections: []auth0v1alpha1.OrganizationConnection{
+					{
+						ConnectionRef: auth0v1alpha1.ConnectionReference{
+							Name: connection.Name,
+						},
+						AssignMembershipOnLogin: true,
+					},
+				},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.Delete(context.Background(), organization)).To(Succeed())
+		Expect(k8sClient.Delete(context.Background(), connection)).To(Succeed())
+
+		Eventually(func() bool {
+			err := k8sClient.Get(ctx, key, &auth0v1alpha1.Organization{})
+			return ctrlclient.IgnoreNotFound(err) == nil
+		}).WithTimeout(timeout).Should(BeTrue())
+	})
+
+	Describe("when an organization is created", func() {
+		JustBeforeEach(func() {
+			// Create the organization before the connection, so it must wait for it
+			Expect(k8sClient.Create(ctx, organization)).To(Succeed())
+			Expect(k8sClient.Create(ctx, connection)).To(Succeed())
+
+			Eventually(func() bool {
+				if err := k8sClient.Get(ctx, key, organization); err != nil {
+					return false
+				}
+				return organization.Status.Auth0Id != ""
+			}).WithTimeout(timeout).Should(BeTrue())
+		})
+
+		It("should create an organization in Auth0 with the correct values", func() {
+			o, err := auth0Api.Organization.Read(ctx, organization.Status.Auth0Id)
+			Expect(err).To(BeNil())
+			Expect(o.GetName()).To(Equal(organization.Spec.Name))
+			Expect(o.GetDisplayName()).To(Equal(organization.Spec.DisplayName))
+			Expect(o.GetMetadata()).To(HaveKeyWithValue("plan", "test"))
+			Expect(o.GetBranding().GetColors()).To(HaveKeyWithValue("primary", "#0059d6"))
+		})
+
+		It("should mark the organization as ready", func() {
+			Eventually(func() bool {
+				if err := k8sClient.Get(ctx, key, organization); err != nil {
+					return false
+				}
+				return meta.IsStatusConditionTrue(organization.Status.Conditions, auth0v1alpha1.ConditionTypeReady)
+			}).WithTimeout(timeout).Should(BeTrue())
+
+			Expect(organization.Status.PendingCreate).To(BeFalse())
+		})
+
+		It("should reuse the organization it created if its ID is lost", func() {
+			createdId := organization.Status.Auth0Id
+
+			// Lose the ID as if the status update after creating it failed
+			organization.Status.Auth0Id = ""
+			organization.Status.PendingCreate = true
+			Expect(k8sClient.Status().Update(ctx, organization)).To(Succeed())
+
+			Eventually(func() string {
+				if err := k8sClient.Get(ctx, key, organization); err != nil {
+					return ""
+				}
+				return organization.Status.Auth0Id
+			}).WithTimeout(timeout).Should(Equal(createdId))
+		})
+
+		When("a metadata key is removed", func() {
+			It("should remove the key in Auth0", func() {
+				organization.Spec.Metadata = nil
+				Expect(k8sClient.Update(ctx, organization)).To(Succeed())
+
+				Eventually(func() (map[string]string, error) {
+					o, err := auth0Api.Organization.Read(ctx, organization.Status.Auth0Id)
+					if err != nil {
+						return nil, err
+					}
+					return o.GetMetadata(), nil
+				}).WithTimeout(timeout).WithPolling(1 * time.Second).ShouldNot(HaveKey("plan"))
+			})
+		})
+
+		It("should enable the connection for the organization", func() {
+			Eventually(func() bool {
+				if err := k8sClient.Get(ctx, key, connection); err != nil {
+					return false
+				}
+
+				c, err := auth0Api.Organization.Connection(ctx, organization.Status.Auth0Id, connection.Status.Auth0Id)
+				if err != nil {
+					return false
+				}
+				return c.GetAssignMembershipOnLogin()
+			}).WithTimeout(timeout).WithPolling(1 * time.Second).Should(BeTrue())
+		})
+	})
+})
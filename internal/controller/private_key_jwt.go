@@ -0,0 +1,91 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/auth0/go-auth0/authentication"
+	"github.com/auth0/go-auth0/authentication/oauth"
+	"golang.org/x/oauth2"
+)
+
+// privateKeyJwtAlg is the algorithm client assertions are signed with
+const privateKeyJwtAlg = "RS256"
+
+// tokenExpiryMargin is how long before they expire tokens are refreshed, so
+// that they don't expire during a reconcile. Short-lived tokens are
+// refreshed once half their lifetime has passed instead
+const tokenExpiryMargin = 5 * time.Minute
+
+// privateKeyJwtTokenSource gets management API tokens with the client
+// credentials flow, authenticating with a client assertion signed by a
+// private key (private_key_jwt) rather than a client secret
+type privateKeyJwtTokenSource struct {
+	auth     *authentication.Authentication
+	audience string
+}
+
+// newPrivateKeyJwtTokenSource returns a token source for the tenant that
+// reuses tokens until they are about to expire
+func newPrivateKeyJwtTokenSource(
+	domain string,
+	clientId string,
+	privateKeyPem []byte,
+	httpClient *http.Client,
+) (oauth2.TokenSource, error) {
+	// authentication.New fetches the keys ID tokens are validated with, and
+	// keeps refreshing them until the context is done. Client credentials
+	// grants don't return ID tokens, so they're only fetched once
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	auth, err := authentication.New(
+		ctx,
+		domain,
+		authentication.WithClientID(clientId),
+		authentication.WithClientAssertion(string(privateKeyPem), privateKeyJwtAlg),
+		authentication.WithClient(httpClient),
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Like the management API client, accept domains with a scheme
+	if i := strings.Index(domain, "//"); i != -1 {
+		domain = domain[i+2:]
+	}
+
+	return oauth2.ReuseTokenSource(nil, &privateKeyJwtTokenSource{
+		auth:     auth,
+		audience: "https://" + domain + "/api/v2/",
+	}), nil
+}
+
+// Token implements oauth2.TokenSource
+func (s *privateKeyJwtTokenSource) Token() (*oauth2.Token, error) {
+	tokens, err := s.auth.OAuth.LoginWithClientCredentials(
+		context.Background(),
+		oauth.LoginWithClientCredentialsRequest{Audience: s.audience},
+		oauth.IDTokenValidationOptions{},
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	lifetime := time.Duration(tokens.ExpiresIn) * time.Second
+
+	margin := tokenExpiryMargin
+	if margin > lifetime/2 {
+		margin = lifetime / 2
+	}
+
+	return &oauth2.Token{
+		AccessToken: tokens.AccessToken,
+		TokenType:   tokens.TokenType,
+		Expiry:      time.Now().Add(lifetime - margin),
+	}, nil
+}
@@ -0,0 +1,93 @@
+package controller
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Private key JWT token source", func() {
+	var server *httptest.Server
+	var form map[string]string
+
+	BeforeEach(func() {
+		form = map[string]string{}
+
+		server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			defer GinkgoRecover()
+
+			w.Header().Set("Content-Type", "application/json")
+
+			// go-auth0 fetches the signing keys of the tenant to validate ID
+			// tokens, which client credentials responses don't have
+			if req.URL.Path == "/.well-known/jwks.json" {
+				_, err := w.Write([]byte(`{"keys":[]}`))
+				Expect(err).ToNot(HaveOccurred())
+				return
+			}
+
+			Expect(req.URL.Path).To(Equal("/oauth/token"))
+			Expect(req.ParseForm()).To(Succeed())
+			for key := range req.PostForm {
+				form[key] = req.PostForm.Get(key)
+			}
+
+			Expect(json.NewEncoder(w).Encode(map[string]interface{}{
+				"access_token": "test-access-token",
+				"token_type":   "Bearer",
+				"expires_in":   86400,
+			})).To(Succeed())
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should get a token with a client assertion signed by the private key", func() {
+		privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		Expect(err).ToNot(HaveOccurred())
+
+		privateKeyPem := pem.EncodeToMemory(&pem.Block{
+			Type:  "RSA PRIVATE KEY",
+			Bytes: x509.MarshalPKCS1PrivateKey(privateKey),
+		})
+
+		domain := strings.TrimPrefix(server.URL, "https://")
+		source, err := newPrivateKeyJwtTokenSource(domain, "test-client-id", privateKeyPem, server.Client())
+		Expect(err).ToNot(HaveOccurred())
+
+		token, err := source.Token()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(token.AccessToken).To(Equal("test-access-token"))
+		Expect(token.Valid()).To(BeTrue())
+
+		Expect(form).To(HaveKeyWithValue("grant_type", "client_credentials"))
+		Expect(form).To(HaveKeyWithValue("client_id", "test-client-id"))
+		Expect(form).To(HaveKeyWithValue("audience", "https://"+domain+"/api/v2/"))
+		Expect(form).To(HaveKeyWithValue("client_assertion_type", "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"))
+		Expect(form).ToNot(HaveKey("client_secret"))
+
+		parts := strings.Split(form["client_assertion"], ".")
+		Expect(parts).To(HaveLen(3))
+
+		header, err := base64.RawURLEncoding.DecodeString(parts[0])
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(header)).To(ContainSubstring(`"RS256"`))
+
+		By("reusing the token until it expires")
+		form = map[string]string{}
+		_, err = source.Token()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(form).To(BeEmpty())
+	})
+})
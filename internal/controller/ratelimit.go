@@ -0,0 +1,26 @@
+package controller
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/rgracey/auth0-operator/internal/ratelimit"
+)
+
+// rateLimitAware requeues reconciles that were rate limited by Auth0 once
+// the rate limit resets, rather than with controller-runtime's exponential
+// backoff which can delay retries for minutes
+func rateLimitAware(r reconcile.Reconciler) reconcile.Reconciler {
+	return reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		result, err := r.Reconcile(ctx, req)
+
+		if retryAfter, ok := ratelimit.RetryAfter(err); ok {
+			log.FromContext(ctx).Info("rate limited by Auth0", "retryAfter", retryAfter)
+			return reconcile.Result{RequeueAfter: retryAfter}, nil
+		}
+
+		return result, err
+	})
+}
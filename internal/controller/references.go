@@ -0,0 +1,251 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	auth0v1alpha1 "github.com/rgracey/auth0-operator/api/v1alpha1"
+)
+
+// resolveClientReference returns the Auth0 ID of the client referenced by
+// a resource managed in the tenant. An empty ID is returned if the
+// referenced Client hasn't been created in Auth0 yet, and an error if it's
+// managed in another tenant
+func resolveClientReference(
+	ctx context.Context,
+	c client.Client,
+	tenants *Tenants,
+	namespace string,
+	tenantRef *auth0v1alpha1.TenantReference,
+	ref auth0v1alpha1.ClientReference,
+) (string, error) {
+	if ref.Auth0Id != "" {
+		return ref.Auth0Id, nil
+	}
+
+	instance := &auth0v1alpha1.Client{}
+	err := c.Get(
+		ctx,
+		client.ObjectKey{
+			Namespace: namespace,
+			Name:      ref.Name,
+		},
+		instance,
+	)
+
+	if err != nil {
+		return "", err
+	}
+
+	err = tenants.checkSameTenant(ctx, namespace, tenantRef, instance.Spec.TenantRef, "client "+ref.Name)
+
+	if err != nil {
+		return "", err
+	}
+
+	return instance.Auth0Id(), nil
+}
+
+// resolveClientReferences returns the Auth0 IDs of the clients referenced
+// by a resource managed in the tenant. Clients that haven't been created in
+// Auth0 yet are omitted
+func resolveClientReferences(
+	ctx context.Context,
+	c client.Client,
+	tenants *Tenants,
+	namespace string,
+	tenantRef *auth0v1alpha1.TenantReference,
+	refs []auth0v1alpha1.ClientReference,
+) ([]string, error) {
+	ids := make([]string, 0, len(refs))
+
+	for _, ref := range refs {
+		id, err := resolveClientReference(ctx, c, tenants, namespace, tenantRef, ref)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if id != "" {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids, nil
+}
+
+// referencesClient returns true if refs contains a reference to the named Client
+func referencesClient(refs []auth0v1alpha1.ClientReference, name string) bool {
+	for _, ref := range refs {
+		if ref.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// resolveConnectionReference returns the Auth0 ID of the connection
+// referenced by a resource managed in the tenant. An empty ID is returned if
+// the referenced Connection hasn't been created in Auth0 yet, and an error
+// if it's managed in another tenant
+func resolveConnectionReference(
+	ctx context.Context,
+	c client.Client,
+	tenants *Tenants,
+	namespace string,
+	tenantRef *auth0v1alpha1.TenantReference,
+	ref auth0v1alpha1.ConnectionReference,
+) (string, error) {
+	if ref.Auth0Id != "" {
+		return ref.Auth0Id, nil
+	}
+
+	instance := &auth0v1alpha1.Connection{}
+	err := c.Get(
+		ctx,
+		client.ObjectKey{
+			Namespace: namespace,
+			Name:      ref.Name,
+		},
+		instance,
+	)
+
+	if err != nil {
+		return "", err
+	}
+
+	err = tenants.checkSameTenant(ctx, namespace, tenantRef, instance.Spec.TenantRef, "connection "+ref.Name)
+
+	if err != nil {
+		return "", err
+	}
+
+	return instance.Auth0Id(), nil
+}
+
+// resolveActionReference returns the Auth0 ID of the action referenced by
+// a resource managed in the tenant. An empty ID is returned if the
+// referenced Action hasn't been deployed yet, and an error if it's managed
+// in another tenant
+func resolveActionReference(
+	ctx context.Context,
+	c client.Client,
+	tenants *Tenants,
+	namespace string,
+	tenantRef *auth0v1alpha1.TenantReference,
+	ref auth0v1alpha1.ActionReference,
+) (string, error) {
+	if ref.Auth0Id != "" {
+		return ref.Auth0Id, nil
+	}
+
+	instance := &auth0v1alpha1.Action{}
+	err := c.Get(
+		ctx,
+		client.ObjectKey{
+			Namespace: namespace,
+			Name:      ref.Name,
+		},
+		instance,
+	)
+
+	if err != nil {
+		return "", err
+	}
+
+	err = tenants.checkSameTenant(ctx, namespace, tenantRef, instance.Spec.TenantRef, "action "+ref.Name)
+
+	if err != nil {
+		return "", err
+	}
+
+	if instance.Status.DeployedVersion == 0 {
+		return "", nil
+	}
+
+	return instance.Auth0Id(), nil
+}
+
+// loadSecretValue returns the value of the referenced key in a Secret
+func loadSecretValue(
+	ctx context.Context,
+	c client.Client,
+	namespace string,
+	ref auth0v1alpha1.SecretRef,
+) (string, error) {
+	value, _, err := loadVersionedSecretValue(ctx, c, namespace, ref)
+	return value, err
+}
+
+// loadVersionedSecretValue returns the value of the referenced key in a
+// Secret, along with the resource version of the Secret
+func loadVersionedSecretValue(
+	ctx context.Context,
+	c client.Client,
+	namespace string,
+	ref auth0v1alpha1.SecretRef,
+) (string, string, error) {
+	secret := &corev1.Secret{}
+	err := c.Get(
+		ctx,
+		client.ObjectKey{
+			Namespace: namespace,
+			Name:      ref.Name,
+		},
+		secret,
+	)
+
+	if err != nil {
+		return "", "", err
+	}
+
+	value, ok := secret.Data[ref.Key]
+
+	if !ok {
+		return "", "", fmt.Errorf(
+			"secret \"%s\" didn't contain key \"%s\"",
+			ref.Name,
+			ref.Key,
+		)
+	}
+
+	return string(value), secret.ResourceVersion, nil
+}
+
+// loadConfigMapValue returns the value of the referenced key in a ConfigMap
+func loadConfigMapValue(
+	ctx context.Context,
+	c client.Client,
+	namespace string,
+	ref auth0v1alpha1.ConfigMapRef,
+) (string, error) {
+	configMap := &corev1.ConfigMap{}
+	err := c.Get(
+		ctx,
+		client.ObjectKey{
+			Namespace: namespace,
+			Name:      ref.Name,
+		},
+		configMap,
+	)
+
+	if err != nil {
+		return "", err
+	}
+
+	value, ok := configMap.Data[ref.Key]
+
+	if !ok {
+		return "", fmt.Errorf(
+			"configmap \"%s\" didn't contain key \"%s\"",
+			ref.Name,
+			ref.Key,
+		)
+	}
+
+	return value, nil
+}
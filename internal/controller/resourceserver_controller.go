@@ -0,0 +1,270 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/auth0/go-auth0/management"
+	auth0v1alpha1 "github.com/rgracey/auth0-operator/api/v1alpha1"
+)
+
+// ResourceServerReconciler reconciles a ResourceServer object
+type ResourceServerReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	Tenants  *Tenants
+}
+
+//+kubebuilder:rbac:groups=auth0.gracey.io,resources=resourceservers,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=auth0.gracey.io,resources=resourceservers/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=auth0.gracey.io,resources=resourceservers/finalizers,verbs=update
+
+// Reconcile moves the Auth0 resource server towards the state specified by
+// the ResourceServer object
+func (r *ResourceServerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	// Fetch the ResourceServer instance
+	instance := &auth0v1alpha1.ResourceServer{}
+	if err := r.Get(ctx, req.NamespacedName, instance); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	// Check if the ResourceServer instance is being deleted, and run finalizer logic.
+	// This comes before resolving the tenant so that deletion isn't blocked
+	// by a tenant that can no longer be used
+	if instance.IsBeingDeleted() {
+		logger.Info("deleting resource server", "name", instance.Spec.Name)
+		return ctrl.Result{}, r.handleFinalizer(ctx, instance)
+	}
+
+	// The ResourceServer is managed in the Auth0 tenant it references. A finalizer
+	// isn't added until the tenant can be used to clean up after it
+	api, err := r.Tenants.Api(ctx, instance.Namespace, instance.Spec.TenantRef)
+
+	if err != nil {
+		logger.Error(err, "unable to get Auth0 tenant")
+		setSyncedCondition(instance, metav1.ConditionFalse, ConditionReasonTenantUnavailable, err.Error())
+
+		if statusErr := updateSyncedStatus(ctx, r.Client, instance, "ResourceServer"); statusErr != nil {
+			logger.Error(statusErr, "unable to update resource server status", "name", instance.Spec.Name)
+		}
+
+		return ctrl.Result{}, err
+	}
+
+	if !hasFinalizer(instance) {
+		return ctrl.Result{Requeue: true}, addFinalizer(ctx, r.Client, instance)
+	}
+
+	result, err := r.reconcileResourceServer(ctx, api, instance)
+
+	if statusErr := updateSyncedStatus(ctx, r.Client, instance, "ResourceServer"); statusErr != nil {
+		logger.Error(statusErr, "unable to update resource server status", "name", instance.Spec.Name)
+
+		if err == nil {
+			err = statusErr
+		}
+	}
+
+	return result, err
+}
+
+// reconcileResourceServer moves the Auth0 resource server towards the state
+// specified by the ResourceServer, recording the outcome in its Synced
+// condition
+func (r *ResourceServerReconciler) reconcileResourceServer(
+	ctx context.Context,
+	api *management.Management,
+	instance *auth0v1alpha1.ResourceServer,
+) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	rs := toAuth0ResourceServer(instance)
+
+	// Reuse a resource server created by a previous reconcile whose status
+	// update failed, rather than failing to create another with the same
+	// identifier
+	if instance.Auth0Id() == "" && instance.Status.PendingCreate {
+		existing, err := api.ResourceServer.Read(ctx, instance.Spec.Identifier)
+
+		if err != nil && !isNotFound(err) {
+			logger.Error(err, "unable to search for existing resource server", "name", instance.Spec.Name)
+			r.Recorder.Event(instance, "Warning", EventReasonCreateFailed, err.Error())
+			setSyncedCondition(instance, metav1.ConditionFalse, EventReasonCreateFailed, err.Error())
+			return ctrl.Result{}, err
+		}
+
+		if err == nil {
+			logger.Info("found existing resource server", "name", instance.Spec.Name, "Auth0 id", existing.GetID())
+			instance.Status.Auth0Id = existing.GetID()
+		}
+
+		instance.Status.PendingCreate = false
+	}
+
+	// Create the ResourceServer if it doesn't exist
+	if instance.Auth0Id() == "" {
+		// Record that a create is pending first, so the resource server is
+		// looked up if its ID can't be recorded after creating it
+		instance.Status.PendingCreate = true
+
+		if err := r.Status().Update(ctx, instance); err != nil {
+			logger.Error(err, "unable to update resource server status", "name", instance.Spec.Name)
+			setSyncedCondition(instance, metav1.ConditionFalse, EventReasonCreateFailed, err.Error())
+			return ctrl.Result{}, err
+		}
+
+		logger.Info("creating resource server", "name", instance.Spec.Name)
+		err := api.ResourceServer.Create(ctx, rs)
+
+		if err != nil {
+			logger.Error(err, "unable to create resource server", "name", instance.Spec.Name)
+			r.Recorder.Event(instance, "Warning", EventReasonCreateFailed, err.Error())
+			setSyncedCondition(instance, metav1.ConditionFalse, EventReasonCreateFailed, err.Error())
+
+			// Auth0 rejected the request, so no resource server was created
+			if isClientError(err) {
+				instance.Status.PendingCreate = false
+			}
+
+			return ctrl.Result{}, err
+		}
+
+		logger.Info("created resource server", "name", instance.Spec.Name, "Auth0 id", rs.GetID())
+
+		// If this fails the resource server is looked up next time
+		instance.Status.Auth0Id = rs.GetID()
+		instance.Status.PendingCreate = false
+		apiErr := r.Status().Update(ctx, instance)
+
+		if apiErr != nil {
+			logger.Error(apiErr, "unable to update resource server status", "name", instance.Spec.Name)
+			instance.Status.Auth0Id = ""
+			instance.Status.PendingCreate = true
+			setSyncedCondition(instance, metav1.ConditionFalse, EventReasonCreateFailed, apiErr.Error())
+			return ctrl.Result{}, apiErr
+		}
+
+		r.Recorder.Event(
+			instance,
+			"Normal",
+			EventReasonCreated,
+			fmt.Sprintf(
+				"Created resource server %s (ID: %s)",
+				instance.Spec.Name,
+				instance.Status.Auth0Id,
+			),
+		)
+
+		setSyncedCondition(instance, metav1.ConditionTrue, EventReasonCreated, "Resource server created in Auth0")
+
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	current, err := api.ResourceServer.Read(ctx, instance.Auth0Id())
+
+	if err != nil {
+		logger.Error(err, "unable to fetch resource server", "name", instance.Spec.Name)
+		r.Recorder.Event(instance, "Warning", EventReasonUpdateFailed, err.Error())
+		setSyncedCondition(instance, metav1.ConditionFalse, EventReasonUpdateFailed, err.Error())
+		return ctrl.Result{}, err
+	}
+
+	// Auth0 doesn't allow updating the identifier
+	rs.Identifier = nil
+
+	// Move ResourceServer to the desired state, if it isn't already
+	if needsUpdate(rs, current) {
+		logger.Info("updating resource server", "name", instance.Spec.Name)
+
+		if err := api.ResourceServer.Update(ctx, instance.Auth0Id(), rs); err != nil {
+			logger.Error(err, "unable to update resource server", "name", instance.Spec.Name)
+			r.Recorder.Event(instance, "Warning", EventReasonUpdateFailed, err.Error())
+			setSyncedCondition(instance, metav1.ConditionFalse, EventReasonUpdateFailed, err.Error())
+			return ctrl.Result{}, err
+		}
+	}
+
+	setSyncedCondition(instance, metav1.ConditionTrue, EventReasonUpdated, "Resource server is in sync with Auth0")
+
+	return ctrl.Result{}, nil
+}
+
+// toAuth0ResourceServer builds the Auth0 representation of a ResourceServer
+func toAuth0ResourceServer(instance *auth0v1alpha1.ResourceServer) *management.ResourceServer {
+	// scopes must be non-nil so that removed scopes are cleared in Auth0
+	scopes := make([]management.ResourceServerScope, 0, len(instance.Spec.Scopes))
+
+	for _, s := range instance.Spec.Scopes {
+		scopes = append(scopes, management.ResourceServerScope{
+			Value:       stringOrNil(s.Value),
+			Description: stringOrNil(s.Description),
+		})
+	}
+
+	return &management.ResourceServer{
+		Name:                stringOrNil(instance.Spec.Name),
+		Identifier:          stringOrNil(instance.Spec.Identifier),
+		Scopes:              &scopes,
+		SigningAlgorithm:    stringOrNil(instance.Spec.SigningAlgorithm),
+		AllowOfflineAccess:  instance.Spec.AllowOfflineAccess,
+		TokenLifetime:       instance.Spec.TokenLifetime,
+		TokenLifetimeForWeb: instance.Spec.TokenLifetimeForWeb,
+		EnforcePolicies:     instance.Spec.EnforcePolicies,
+		TokenDialect:        stringOrNil(instance.Spec.TokenDialect),
+		SkipConsentForVerifiableFirstPartyClients: instance.Spec.SkipConsentForVerifiableFirstPartyClients,
+	}
+}
+
+// resourceServersForTenantSecret maps a Secret to the ResourceServers managed
+// in the tenants whose credentials it holds
+func (r *ResourceServerReconciler) resourceServersForTenantSecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	return requestsForTenantSecret(ctx, r.Client, r.Tenants, &auth0v1alpha1.ResourceServerList{}, obj)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ResourceServerReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	err := indexByTenant(mgr, r.Tenants, &auth0v1alpha1.ResourceServer{}, func(obj client.Object) *auth0v1alpha1.TenantReference {
+		return obj.(*auth0v1alpha1.ResourceServer).Spec.TenantRef
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&auth0v1alpha1.ResourceServer{}).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.resourceServersForTenantSecret),
+		).
+		Complete(rateLimitAware(r))
+}
@@ -0,0 +1,157 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/auth0/go-auth0/management"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	auth0v1alpha1 "github.com/rgracey/auth0-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+var _ = Describe("ResourceServer controller", func() {
+	var key types.NamespacedName
+
+	var resourceServer *auth0v1alpha1.ResourceServer
+
+	var auth0ResourceServer *management.ResourceServer
+
+	BeforeEach(func() {
+		suffix := time.Now().Format("20060102150405")
+
+		key = types.NamespacedName{
+			Name:      "test-resource-server-" + suffix,
+			Namespace: "default",
+		}
+		resourceServer = &auth0v1alpha1.ResourceServer{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+			},
+			Spec: auth0v1alpha1.ResourceServerSpec{
+				Name:             "test-suite-resource-server",
+				Identifier:       "https://test-suite-" + suffix + ".example.com",
+				SigningAlgorithm: "RS256",
+				Scopes: []auth0v1alpha1.ResourceServerScope{
+					{Value: "read:test", Description: "Read test"},
+				},
+			},
+		}
+	})
+
+	Describe("when a resource server is created", func() {
+		AfterEach(func() {
+			Expect(k8sClient.Delete(context.Background(), resourceServer)).To(Succeed())
+
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, key, &auth0v1alpha1.ResourceServer{})
+				return ctrlclient.IgnoreNotFound(err) == nil
+			}).WithTimeout(timeout).Should(BeTrue())
+		})
+
+		JustBeforeEach(func() {
+			Expect(k8sClient.Create(ctx, resourceServer)).To(Succeed())
+
+			// Wait for the Auth0 ID to be populated and finalizer to be added
+			Eventually(func() bool {
+				if err := k8sClient.Get(ctx, key, resourceServer); err != nil {
+					return false
+				}
+				return resourceServer.Status.Auth0Id != "" &&
+					controllerutil.ContainsFinalizer(resourceServer, finalizerName)
+			}).WithTimeout(timeout).Should(BeTrue())
+
+			var err error
+			auth0ResourceServer, err = auth0Api.ResourceServer.Read(ctx, resourceServer.Status.Auth0Id)
+			Expect(err).To(BeNil())
+			Expect(auth0ResourceServer).ToNot(BeNil())
+		})
+
+		It("should create a resource server in Auth0 with the correct values", func() {
+			Expect(auth0ResourceServer.GetName()).To(Equal(resourceServer.Spec.Name))
+			Expect(auth0ResourceServer.GetIdentifier()).To(Equal(resourceServer.Spec.Identifier))
+			Expect(auth0ResourceServer.GetSigningAlgorithm()).To(Equal(resourceServer.Spec.SigningAlgorithm))
+			Expect(auth0ResourceServer.GetScopes()).To(HaveLen(1))
+			Expect(auth0ResourceServer.GetScopes()[0].GetValue()).To(Equal("read:test"))
+		})
+
+		It("should mark the resource server as ready", func() {
+			Eventually(func() bool {
+				if err := k8sClient.Get(ctx, key, resourceServer); err != nil {
+					return false
+				}
+				return meta.IsStatusConditionTrue(resourceServer.Status.Conditions, auth0v1alpha1.ConditionTypeReady)
+			}).WithTimeout(timeout).Should(BeTrue())
+
+			Expect(meta.IsStatusConditionTrue(resourceServer.Status.Conditions, auth0v1alpha1.ConditionTypeSynced)).To(BeTrue())
+			Expect(resourceServer.Status.PendingCreate).To(BeFalse())
+		})
+
+		It("should reuse the resource server it created if its ID is lost", func() {
+			createdId := resourceServer.Status.Auth0Id
+
+			// Lose the ID as if the status update after creating it failed
+			resourceServer.Status.Auth0Id = ""
+			resourceServer.Status.PendingCreate = true
+			Expect(k8sClient.Status().Update(ctx, resourceServer)).To(Succeed())
+
+			Eventually(func() string {
+				if err := k8sClient.Get(ctx, key, resourceServer); err != nil {
+					return ""
+				}
+				return resourceServer.Status.Auth0Id
+			}).WithTimeout(timeout).Should(Equal(createdId))
+		})
+
+		When("the scopes are changed", func() {
+			It("should update the scopes in Auth0", func() {
+				resourceServer.Spec.Scopes = append(
+					resourceServer.Spec.Scopes,
+					auth0v1alpha1.ResourceServerScope{Value: "write:test"},
+				)
+				Expect(k8sClient.Update(ctx, resourceServer)).To(Succeed())
+
+				Eventually(func() int {
+					rs, err := auth0Api.ResourceServer.Read(ctx, resourceServer.Status.Auth0Id)
+					if err != nil {
+						return 0
+					}
+					return len(rs.GetScopes())
+				}).WithTimeout(timeout).WithPolling(1 * time.Second).Should(Equal(2))
+			})
+		})
+	})
+
+	Describe("when a resource server is deleted", func() {
+		JustBeforeEach(func() {
+			Expect(k8sClient.Create(ctx, resourceServer)).To(Succeed())
+
+			Eventually(func() bool {
+				if err := k8sClient.Get(ctx, key, resourceServer); err != nil {
+					return false
+				}
+				return resourceServer.Status.Auth0Id != ""
+			}).WithTimeout(timeout).Should(BeTrue())
+		})
+
+		It("should delete the resource server in Auth0", func() {
+			Expect(k8sClient.Delete(context.Background(), resourceServer)).To(Succeed())
+
+			Eventually(func() bool {
+				_, err := auth0Api.ResourceServer.Read(ctx, resourceServer.Status.Auth0Id)
+				if err == nil {
+					return false
+				}
+
+				Expect(err.Error()).To(ContainSubstring("Not Found"))
+				return true
+			}).WithTimeout(timeout).WithPolling(1 * time.Second).Should(BeTrue())
+		})
+	})
+})
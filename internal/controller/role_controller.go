@@ -0,0 +1,401 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/auth0/go-auth0/management"
+	auth0v1alpha1 "github.com/rgracey/auth0-operator/api/v1alpha1"
+)
+
+// RoleReconciler reconciles a Role object
+type RoleReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	Tenants  *Tenants
+}
+
+//+kubebuilder:rbac:groups=auth0.gracey.io,resources=roles,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=auth0.gracey.io,resources=roles/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=auth0.gracey.io,resources=roles/finalizers,verbs=update
+
+// Reconcile moves the Auth0 role towards the state specified by
+// the Role object
+func (r *RoleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	// Fetch the Role instance
+	instance := &auth0v1alpha1.Role{}
+	if err := r.Get(ctx, req.NamespacedName, instance); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	// Check if the Role instance is being deleted, and run finalizer logic.
+	// This comes before resolving the tenant so that deletion isn't blocked
+	// by a tenant that can no longer be used
+	if instance.IsBeingDeleted() {
+		logger.Info("deleting role", "name", instance.Spec.Name)
+		return ctrl.Result{}, r.handleFinalizer(ctx, instance)
+	}
+
+	// The Role is managed in the Auth0 tenant it references. A finalizer
+	// isn't added until the tenant can be used to clean up after it
+	api, err := r.Tenants.Api(ctx, instance.Namespace, instance.Spec.TenantRef)
+
+	if err != nil {
+		logger.Error(err, "unable to get Auth0 tenant")
+		setSyncedCondition(instance, metav1.ConditionFalse, ConditionReasonTenantUnavailable, err.Error())
+
+		if statusErr := updateSyncedStatus(ctx, r.Client, instance, "Role"); statusErr != nil {
+			logger.Error(statusErr, "unable to update role status", "name", instance.Spec.Name)
+		}
+
+		return ctrl.Result{}, err
+	}
+
+	if !hasFinalizer(instance) {
+		return ctrl.Result{Requeue: true}, addFinalizer(ctx, r.Client, instance)
+	}
+
+	result, err := r.reconcileRole(ctx, api, instance)
+
+	if statusErr := updateSyncedStatus(ctx, r.Client, instance, "Role"); statusErr != nil {
+		logger.Error(statusErr, "unable to update role status", "name", instance.Spec.Name)
+
+		if err == nil {
+			err = statusErr
+		}
+	}
+
+	return result, err
+}
+
+// reconcileRole moves the Auth0 role and its permissions towards the state
+// specified by the Role, recording the outcome in its Synced condition
+func (r *RoleReconciler) reconcileRole(
+	ctx context.Context,
+	api *management.Management,
+	instance *auth0v1alpha1.Role,
+) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	// Reuse a role created by a previous reconcile whose status update
+	// failed, rather than creating another with the same name
+	if instance.Auth0Id() == "" && instance.Status.PendingCreate {
+		existing, err := r.findRole(ctx, api, instance.Spec.Name)
+
+		if err != nil {
+			logger.Error(err, "unable to search for existing role", "name", instance.Spec.Name)
+			r.Recorder.Event(instance, "Warning", EventReasonCreateFailed, err.Error())
+			setSyncedCondition(instance, metav1.ConditionFalse, EventReasonCreateFailed, err.Error())
+			return ctrl.Result{}, err
+		}
+
+		if existing != nil {
+			logger.Info("found existing role", "name", instance.Spec.Name, "Auth0 id", existing.GetID())
+			instance.Status.Auth0Id = existing.GetID()
+		}
+
+		instance.Status.PendingCreate = false
+	}
+
+	// Create the Role if it doesn't exist
+	if instance.Auth0Id() == "" {
+		role := &management.Role{
+			Name:        &instance.Spec.Name,
+			Description: stringOrNil(instance.Spec.Description),
+		}
+
+		// Record that a create is pending first, so the role is looked up if
+		// its ID can't be recorded after creating it
+		instance.Status.PendingCreate = true
+
+		if err := r.Status().Update(ctx, instance); err != nil {
+			logger.Error(err, "unable to update role status", "name", instance.Spec.Name)
+			setSyncedCondition(instance, metav1.ConditionFalse, EventReasonCreateFailed, err.Error())
+			return ctrl.Result{}, err
+		}
+
+		logger.Info("creating role", "name", instance.Spec.Name)
+		err := api.Role.Create(ctx, role)
+
+		if err != nil {
+			logger.Error(err, "unable to create role", "name", instance.Spec.Name)
+			r.Recorder.Event(instance, "Warning", EventReasonCreateFailed, err.Error())
+			setSyncedCondition(instance, metav1.ConditionFalse, EventReasonCreateFailed, err.Error())
+
+			// Auth0 rejected the request, so no role was created
+			if isClientError(err) {
+				instance.Status.PendingCreate = false
+			}
+
+			return ctrl.Result{}, err
+		}
+
+		logger.Info("created role", "name", instance.Spec.Name, "Auth0 id", role.GetID())
+
+		// If this fails the role is looked up next time
+		instance.Status.Auth0Id = role.GetID()
+		instance.Status.PendingCreate = false
+		apiErr := r.Status().Update(ctx, instance)
+
+		if apiErr != nil {
+			logger.Error(apiErr, "unable to update role status", "name", instance.Spec.Name)
+			instance.Status.Auth0Id = ""
+			instance.Status.PendingCreate = true
+			setSyncedCondition(instance, metav1.ConditionFalse, EventReasonCreateFailed, apiErr.Error())
+			return ctrl.Result{}, apiErr
+		}
+
+		r.Recorder.Event(
+			instance,
+			"Normal",
+			EventReasonCreated,
+			fmt.Sprintf(
+				"Created role %s (ID: %s)",
+				instance.Spec.Name,
+				instance.Status.Auth0Id,
+			),
+		)
+	} else {
+		current, err := api.Role.Read(ctx, instance.Auth0Id())
+
+		if err != nil {
+			logger.Error(err, "unable to fetch role", "name", instance.Spec.Name)
+			r.Recorder.Event(instance, "Warning", EventReasonUpdateFailed, err.Error())
+			setSyncedCondition(instance, metav1.ConditionFalse, EventReasonUpdateFailed, err.Error())
+			return ctrl.Result{}, err
+		}
+
+		if current.GetName() != instance.Spec.Name || current.GetDescription() != instance.Spec.Description {
+			logger.Info("updating role", "name", instance.Spec.Name)
+
+			err := api.Role.Update(ctx, instance.Auth0Id(), &management.Role{
+				Name:        &instance.Spec.Name,
+				Description: &instance.Spec.Description,
+			})
+
+			if err != nil {
+				logger.Error(err, "unable to update role", "name", instance.Spec.Name)
+				r.Recorder.Event(instance, "Warning", EventReasonUpdateFailed, err.Error())
+				setSyncedCondition(instance, metav1.ConditionFalse, EventReasonUpdateFailed, err.Error())
+				return ctrl.Result{}, err
+			}
+		}
+	}
+
+	if err := r.syncPermissions(ctx, api, instance); err != nil {
+		logger.Error(err, "unable to update role permissions", "name", instance.Spec.Name)
+		r.Recorder.Event(instance, "Warning", EventReasonUpdateFailed, err.Error())
+		setSyncedCondition(instance, metav1.ConditionFalse, EventReasonUpdateFailed, err.Error())
+		return ctrl.Result{}, err
+	}
+
+	setSyncedCondition(instance, metav1.ConditionTrue, EventReasonUpdated, "Role is in sync with Auth0")
+
+	return ctrl.Result{}, nil
+}
+
+// findRole returns the Auth0 role with the given name, or nil if there
+// isn't one. Auth0 only filters roles by a case insensitive substring of
+// their name, so the matches are checked for the exact name
+func (r *RoleReconciler) findRole(ctx context.Context, api *management.Management, name string) (*management.Role, error) {
+	for page := 0; ; page++ {
+		list, err := api.Role.List(ctx, management.Parameter("name_filter", name), management.Page(page))
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, role := range list.Roles {
+			if role.GetName() == name {
+				return role, nil
+			}
+		}
+
+		if !list.HasNext() {
+			return nil, nil
+		}
+	}
+}
+
+// syncPermissions adds the permissions of the Role that are missing in
+// Auth0 and removes those that are no longer declared
+func (r *RoleReconciler) syncPermissions(ctx context.Context, api *management.Management, instance *auth0v1alpha1.Role) error {
+	current, err := r.listPermissions(ctx, api, instance.Auth0Id())
+
+	if err != nil {
+		return err
+	}
+
+	desired := map[auth0v1alpha1.RolePermission]bool{}
+	for _, p := range instance.Spec.Permissions {
+		desired[p] = true
+	}
+
+	existing := map[auth0v1alpha1.RolePermission]bool{}
+	var remove []*management.Permission
+	for _, p := range current {
+		key := auth0v1alpha1.RolePermission{
+			ResourceServerIdentifier: p.GetResourceServerIdentifier(),
+			Scope:                    p.GetName(),
+		}
+
+		existing[key] = true
+
+		if !desired[key] {
+			remove = append(remove, rolePermission(key))
+		}
+	}
+
+	var add []*management.Permission
+	for _, p := range instance.Spec.Permissions {
+		if !existing[p] {
+			add = append(add, rolePermission(p))
+		}
+	}
+
+	if len(add) > 0 {
+		if err := api.Role.AssociatePermissions(ctx, instance.Auth0Id(), add); err != nil {
+			return err
+		}
+	}
+
+	if len(remove) > 0 {
+		if err := api.Role.RemovePermissions(ctx, instance.Auth0Id(), remove); err != nil {
+			return err
+		}
+	}
+
+	if len(add) > 0 || len(remove) > 0 {
+		r.Recorder.Event(
+			instance,
+			"Normal",
+			EventReasonUpdated,
+			fmt.Sprintf(
+				"Updated permissions of role %s (%d added, %d removed)",
+				instance.Spec.Name,
+				len(add),
+				len(remove),
+			),
+		)
+	}
+
+	return nil
+}
+
+// listPermissions returns all permissions of the role with the given ID
+func (r *RoleReconciler) listPermissions(ctx context.Context, api *management.Management, id string) ([]*management.Permission, error) {
+	var permissions []*management.Permission
+
+	for page := 0; ; page++ {
+		list, err := api.Role.Permissions(ctx, id, management.Page(page))
+
+		if err != nil {
+			return nil, err
+		}
+
+		permissions = append(permissions, list.Permissions...)
+
+		if !list.HasNext() {
+			return permissions, nil
+		}
+	}
+}
+
+// rolePermission converts a RolePermission to an Auth0 permission
+func rolePermission(p auth0v1alpha1.RolePermission) *management.Permission {
+	return &management.Permission{
+		ResourceServerIdentifier: &p.ResourceServerIdentifier,
+		Name:                     &p.Scope,
+	}
+}
+
+// rolesForResourceServer maps a ResourceServer to the Roles that grant
+// its scopes, so permissions are retried once the scopes exist
+func (r *RoleReconciler) rolesForResourceServer(ctx context.Context, obj client.Object) []reconcile.Request {
+	resourceServer, ok := obj.(*auth0v1alpha1.ResourceServer)
+	if !ok {
+		return nil
+	}
+
+	roles := &auth0v1alpha1.RoleList{}
+	if err := r.List(ctx, roles, client.InNamespace(obj.GetNamespace())); err != nil {
+		log.FromContext(ctx).Error(err, "unable to list roles")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, role := range roles.Items {
+		for _, p := range role.Spec.Permissions {
+			if p.ResourceServerIdentifier == resourceServer.Spec.Identifier {
+				requests = append(requests, reconcile.Request{
+					NamespacedName: types.NamespacedName{
+						Namespace: role.Namespace,
+						Name:      role.Name,
+					},
+				})
+				break
+			}
+		}
+	}
+
+	return requests
+}
+
+// rolesForTenantSecret maps a Secret to the Roles managed in the tenants whose
+// credentials it holds
+func (r *RoleReconciler) rolesForTenantSecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	return requestsForTenantSecret(ctx, r.Client, r.Tenants, &auth0v1alpha1.RoleList{}, obj)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *RoleReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	err := indexByTenant(mgr, r.Tenants, &auth0v1alpha1.Role{}, func(obj client.Object) *auth0v1alpha1.TenantReference {
+		return obj.(*auth0v1alpha1.Role).Spec.TenantRef
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&auth0v1alpha1.Role{}).
+		Watches(
+			&auth0v1alpha1.ResourceServer{},
+			handler.EnqueueRequestsFromMapFunc(r.rolesForResourceServer),
+		).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.rolesForTenantSecret),
+		).
+		Complete(rateLimitAware(r))
+}
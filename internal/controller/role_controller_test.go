@@ -0,0 +1,145 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/auth0/go-auth0/management"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	auth0v1alpha1 "github.com/rgracey/auth0-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Role controller", func() {
+	var key types.NamespacedName
+
+	var role *auth0v1alpha1.Role
+
+	var resourceServer *management.ResourceServer
+
+	// permissions returns the permissions of the role in Auth0 as scopes
+	permissions := func() []string {
+		list, err := auth0Api.Role.Permissions(ctx, role.Status.Auth0Id)
+		if err != nil {
+			return nil
+		}
+
+		var scopes []string
+		for _, p := range list.Permissions {
+			scopes = append(scopes, p.GetName())
+		}
+		return scopes
+	}
+
+	BeforeEach(func() {
+		suffix := time.Now().Format("20060102150405")
+
+		// The API whose scopes are granted by the role
+		identifier := "https://test-suite-role-" + suffix + ".example.com"
+		resourceServer = &management.ResourceServer{
+			Name:       &identifier,
+			Identifier: &identifier,
+			Scopes: &[]management.ResourceServerScope{
+				{Value: stringOrNil("read:test")},
+				{Value: stringOrNil("write:test")},
+			},
+		}
+		Expect(auth0Api.ResourceServer.Create(ctx, resourceServer)).To(Succeed())
+
+		key = types.NamespacedName{
+			Name:      "test-role-" + suffix,
+			Namespace: "default",
+		}
+		role = &auth0v1alpha1.Role{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+			},
+			Spec: auth0v1alpha1.RoleSpec{
+				Name:        "test-suite-role-" + suffix,
+				Description: "Created by the test suite",
+				Permissions: []auth0v1alpha1.RolePermission{
+					{ResourceServerIdentifier: identifier, Scope: "read:test"},
+				},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.Delete(context.Background(), role)).To(Succeed())
+
+		Eventually(func() bool {
+			err := k8sClient.Get(ctx, key, &auth0v1alpha1.Role{})
+			return ctrlclient.IgnoreNotFound(err) == nil
+		}).WithTimeout(timeout).Should(BeTrue())
+
+		Expect(auth0Api.ResourceServer.Delete(ctx, resourceServer.GetID())).To(Succeed())
+	})
+
+	Describe("when a role is created", func() {
+		JustBeforeEach(func() {
+			Expect(k8sClient.Create(ctx, role)).To(Succeed())
+
+			Eventually(func() bool {
+				if err := k8sClient.Get(ctx, key, role); err != nil {
+					return false
+				}
+				return role.Status.Auth0Id != ""
+			}).WithTimeout(timeout).Should(BeTrue())
+		})
+
+		It("should create a role in Auth0 with the correct values", func() {
+			r, err := auth0Api.Role.Read(ctx, role.Status.Auth0Id)
+			Expect(err).To(BeNil())
+			Expect(r.GetName()).To(Equal(role.Spec.Name))
+			Expect(r.GetDescription()).To(Equal(role.Spec.Description))
+
+			Eventually(permissions).WithTimeout(timeout).WithPolling(1 * time.Second).Should(ConsistOf("read:test"))
+		})
+
+		It("should mark the role as ready", func() {
+			Eventually(func() bool {
+				if err := k8sClient.Get(ctx, key, role); err != nil {
+					return false
+				}
+				return meta.IsStatusConditionTrue(role.Status.Conditions, auth0v1alpha1.ConditionTypeReady)
+			}).WithTimeout(timeout).Should(BeTrue())
+
+			Expect(role.Status.PendingCreate).To(BeFalse())
+		})
+
+		It("should reuse the role it created if its ID is lost", func() {
+			createdId := role.Status.Auth0Id
+
+			// Lose the ID as if the status update after creating it failed
+			role.Status.Auth0Id = ""
+			role.Status.PendingCreate = true
+			Expect(k8sClient.Status().Update(ctx, role)).To(Succeed())
+
+			Eventually(func() string {
+				if err := k8sClient.Get(ctx, key, role); err != nil {
+					return ""
+				}
+				return role.Status.Auth0Id
+			}).WithTimeout(timeout).Should(Equal(createdId))
+		})
+
+		When("the permissions are changed", func() {
+			It("should add and remove permissions in Auth0", func() {
+				Eventually(permissions).WithTimeout(timeout).WithPolling(1 * time.Second).Should(ConsistOf("read:test"))
+
+				Expect(k8sClient.Get(ctx, key, role)).To(Succeed())
+				role.Spec.Permissions = []auth0v1alpha1.RolePermission{
+					{ResourceServerIdentifier: resourceServer.GetIdentifier(), Scope: "write:test"},
+				}
+				Expect(k8sClient.Update(ctx, role)).To(Succeed())
+
+				Eventually(permissions).WithTimeout(timeout).WithPolling(1 * time.Second).Should(ConsistOf("write:test"))
+			})
+		})
+	})
+})
@@ -0,0 +1,83 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	auth0v1alpha1 "github.com/rgracey/auth0-operator/api/v1alpha1"
+)
+
+// conditionsObject is a resource that records its observed state in
+// conditions
+type conditionsObject interface {
+	client.Object
+	StatusConditions() *[]metav1.Condition
+}
+
+// setSyncedCondition sets the Synced condition of the resource
+func setSyncedCondition(
+	instance conditionsObject,
+	status metav1.ConditionStatus,
+	reason string,
+	message string,
+) {
+	setCondition(instance, auth0v1alpha1.ConditionTypeSynced, status, reason, message)
+}
+
+// setCondition sets a condition of the resource for its current generation
+func setCondition(
+	instance conditionsObject,
+	conditionType string,
+	status metav1.ConditionStatus,
+	reason string,
+	message string,
+) {
+	meta.SetStatusCondition(instance.StatusConditions(), metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		ObservedGeneration: instance.GetGeneration(),
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// updateSyncedStatus sets the Ready condition of a resource that's ready
+// once it's synced with Auth0, and updates its status. kind names the
+// resource in the condition messages
+func updateSyncedStatus(
+	ctx context.Context,
+	c client.Client,
+	instance conditionsObject,
+	kind string,
+) error {
+	synced := meta.FindStatusCondition(*instance.StatusConditions(), auth0v1alpha1.ConditionTypeSynced)
+
+	switch {
+	case synced == nil:
+		setCondition(
+			instance,
+			auth0v1alpha1.ConditionTypeReady,
+			metav1.ConditionFalse,
+			ConditionReasonNotSynced,
+			fmt.Sprintf("%s hasn't been synced with Auth0", kind),
+		)
+
+	case synced.Status != metav1.ConditionTrue:
+		setCondition(instance, auth0v1alpha1.ConditionTypeReady, metav1.ConditionFalse, ConditionReasonNotSynced, synced.Message)
+
+	default:
+		setCondition(
+			instance,
+			auth0v1alpha1.ConditionTypeReady,
+			metav1.ConditionTrue,
+			ConditionReasonReady,
+			fmt.Sprintf("%s is ready", kind),
+		)
+	}
+
+	return c.Status().Update(ctx, instance)
+}
@@ -28,6 +28,8 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -118,11 +120,97 @@ var _ = BeforeSuite(func() {
 	Expect(err).ToNot(HaveOccurred())
 	Expect(auth0Api).ToNot(BeNil())
 
+	// Manage resources in the tenant of the test suite by default
+	Expect(k8sClient.Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-suite-tenant",
+			Namespace: "default",
+		},
+		StringData: map[string]string{
+			"client-secret": clientSecret,
+		},
+	})).To(Succeed())
+
+	Expect(k8sClient.Create(ctx, &auth0v1alpha1.Auth0Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-suite",
+		},
+		Spec: auth0v1alpha1.Auth0TenantSpec{
+			Domain:   domain,
+			ClientId: clientID,
+			ClientSecretRef: &auth0v1alpha1.NamespacedSecretRef{
+				Namespace: "default",
+				Name:      "test-suite-tenant",
+				Key:       "client-secret",
+			},
+			AllowedNamespaces: []string{"default"},
+		},
+	})).To(Succeed())
+
+	tenants := NewTenants(k8sManager.GetClient(), "test-suite")
+
 	err = (&ClientReconciler{
+		Client:    k8sManager.GetClient(),
+		Scheme:    k8sManager.GetScheme(),
+		Recorder:  k8sManager.GetEventRecorderFor("auth0-controller"),
+		Tenants:   tenants,
+		APIReader: k8sManager.GetAPIReader(),
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&ResourceServerReconciler{
+		Client:   k8sManager.GetClient(),
+		Scheme:   k8sManager.GetScheme(),
+		Recorder: k8sManager.GetEventRecorderFor("auth0-controller"),
+		Tenants:  tenants,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&ClientGrantReconciler{
+		Client:   k8sManager.GetClient(),
+		Scheme:   k8sManager.GetScheme(),
+		Recorder: k8sManager.GetEventRecorderFor("auth0-controller"),
+		Tenants:  tenants,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&ConnectionReconciler{
+		Client:   k8sManager.GetClient(),
+		Scheme:   k8sManager.GetScheme(),
+		Recorder: k8sManager.GetEventRecorderFor("auth0-controller"),
+		Tenants:  tenants,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&RoleReconciler{
+		Client:   k8sManager.GetClient(),
+		Scheme:   k8sManager.GetScheme(),
+		Recorder: k8sManager.GetEventRecorderFor("auth0-controller"),
+		Tenants:  tenants,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&OrganizationReconciler{
+		Client:   k8sManager.GetClient(),
+		Scheme:   k8sManager.GetScheme(),
+		Recorder: k8sManager.GetEventRecorderFor("auth0-controller"),
+		Tenants:  tenants,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&ActionReconciler{
+		Client:   k8sManager.GetClient(),
+		Scheme:   k8sManager.GetScheme(),
+		Recorder: k8sManager.GetEventRecorderFor("auth0-controller"),
+		Tenants:  tenants,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&TriggerBindingReconciler{
 		Client:   k8sManager.GetClient(),
 		Scheme:   k8sManager.GetScheme(),
 		Recorder: k8sManager.GetEventRecorderFor("auth0-controller"),
-		Auth0Api: auth0Api,
+		Tenants:  tenants,
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
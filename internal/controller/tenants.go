@@ -0,0 +1,581 @@
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/auth0/go-auth0/management"
+	auth0v1alpha1 "github.com/rgracey/auth0-operator/api/v1alpha1"
+	"github.com/rgracey/auth0-operator/internal/ratelimit"
+)
+
+//+kubebuilder:rbac:groups=auth0.gracey.io,resources=auth0tenants,verbs=get;list;watch
+//+kubebuilder:rbac:groups=auth0.gracey.io,resources=namespacedauth0tenants,verbs=get;list;watch
+
+// Tenants builds the Auth0 management API clients of Auth0Tenants and
+// NamespacedAuth0Tenants, caching them until the tenant or its credentials
+// change
+type Tenants struct {
+	client client.Reader
+
+	// The Auth0Tenant of resources that don't reference one
+	defaultTenant string
+
+	// The tenant configured by environment variables, used by resources that
+	// don't reference a tenant when there's no default Auth0Tenant
+	environmentTenant *tenant
+
+	// Guards apis, but not the clients in it, which are built and refreshed
+	// while holding the lock of their tenantApi only
+	mu   sync.Mutex
+	apis map[string]*tenantApi
+}
+
+// unusableTenantError is returned when a tenant can't be used by a resource
+// for a reason that retrying won't fix
+type unusableTenantError struct {
+	message string
+}
+
+func (e *unusableTenantError) Error() string {
+	return e.message
+}
+
+// isTenantUnusable returns true if err means the tenant can't be used until
+// it or its credentials are fixed, rather than being a transient failure
+func isTenantUnusable(err error) bool {
+	var unusable *unusableTenantError
+	return errors.As(err, &unusable) || apierrors.IsNotFound(err) || errors.Is(err, fs.ErrNotExist)
+}
+
+// tenantApi is a management API client built for a generation of a tenant
+// and a version of its credentials
+type tenantApi struct {
+	// Held while the client is built or refreshed, so that concurrent
+	// reconciles of the tenant wait for a single request to Auth0
+	mu sync.Mutex
+
+	generation        int64
+	credentialVersion string
+	api               *management.Management
+
+	// Tenants authenticated with private_key_jwt get tokens from tokenSource,
+	// and api is rebuilt whenever token changes
+	tokenSource oauth2.TokenSource
+	token       string
+}
+
+// tenant is a resolved Auth0Tenant or NamespacedAuth0Tenant
+type tenant struct {
+	// Uniquely identifies the tenant across kinds and namespaces
+	key        string
+	generation int64
+
+	domain   string
+	clientId string
+
+	// The client secret or private key is held in either a secret or a file,
+	// or is known up front for the environment tenant
+	secretRef      types.NamespacedName
+	secretKey      string
+	privateKeyFile string
+	credential     []byte
+
+	// Whether the credential is a private key rather than a client secret
+	privateKey bool
+}
+
+// NewTenants returns Tenants that reads tenants and their secrets with c
+func NewTenants(c client.Reader, defaultTenant string) *Tenants {
+	return &Tenants{
+		client:        c,
+		defaultTenant: defaultTenant,
+		apis:          map[string]*tenantApi{},
+	}
+}
+
+// environmentTenantKey is the key of the tenant configured by environment
+// variables
+const environmentTenantKey = "Environment"
+
+// UseEnvironmentTenant manages resources that don't reference a tenant in
+// the tenant with the domain and client credentials, unless a default
+// Auth0Tenant is set. This is how the operator was configured before
+// Auth0Tenants, so resources in any namespace may use it
+func (t *Tenants) UseEnvironmentTenant(domain string, clientId string, clientSecret string) {
+	t.environmentTenant = &tenant{
+		key:        environmentTenantKey,
+		domain:     domain,
+		clientId:   clientId,
+		credential: []byte(clientSecret),
+	}
+}
+
+// Api returns the management API client of the tenant referenced by a
+// resource in the namespace, or of the default tenant if ref is nil. The
+// client is rebuilt whenever the tenant or its credentials change
+func (t *Tenants) Api(
+	ctx context.Context,
+	namespace string,
+	ref *auth0v1alpha1.TenantReference,
+) (*management.Management, error) {
+	tenant, err := t.resolve(ctx, namespace, ref)
+
+	if err != nil {
+		return nil, err
+	}
+
+	credential, version, err := t.loadCredential(ctx, tenant)
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to load credentials of tenant %s: %w", tenant.key, err)
+	}
+
+	t.mu.Lock()
+	cached, ok := t.apis[tenant.key]
+	if !ok {
+		cached = &tenantApi{}
+		t.apis[tenant.key] = cached
+	}
+	t.mu.Unlock()
+
+	cached.mu.Lock()
+	defer cached.mu.Unlock()
+
+	if !cached.built() || cached.generation != tenant.generation || cached.credentialVersion != version {
+		if err := cached.build(tenant, credential); err != nil {
+			return nil, fmt.Errorf("unable to create api client for tenant %s: %w", tenant.key, err)
+		}
+
+		cached.generation = tenant.generation
+		cached.credentialVersion = version
+	}
+
+	if cached.tokenSource != nil {
+		if err := cached.refresh(tenant); err != nil {
+			return nil, fmt.Errorf("unable to get token for tenant %s: %w", tenant.key, err)
+		}
+	}
+
+	return cached.api, nil
+}
+
+// loadCredential returns the client secret or private key of the tenant,
+// along with a version that changes whenever the credential does
+func (t *Tenants) loadCredential(ctx context.Context, tenant *tenant) ([]byte, string, error) {
+	if tenant.credential != nil {
+		return tenant.credential, "", nil
+	}
+
+	if tenant.privateKeyFile != "" {
+		credential, err := os.ReadFile(tenant.privateKeyFile)
+
+		if err != nil {
+			return nil, "", err
+		}
+
+		hash := sha256.Sum256(credential)
+		return credential, hex.EncodeToString(hash[:]), nil
+	}
+
+	secret := &corev1.Secret{}
+	if err := t.client.Get(ctx, tenant.secretRef, secret); err != nil {
+		return nil, "", err
+	}
+
+	credential, ok := secret.Data[tenant.secretKey]
+	if !ok {
+		return nil, "", fmt.Errorf("key %s not found in secret %s", tenant.secretKey, tenant.secretRef)
+	}
+
+	return credential, secret.ResourceVersion, nil
+}
+
+// tokenRequestTimeout bounds requests for tokens, and for the keys they're
+// validated with, including the time spent waiting on the rate limit
+const tokenRequestTimeout = 30 * time.Second
+
+// built returns true if the client has been built
+func (a *tenantApi) built() bool {
+	return a.api != nil || a.tokenSource != nil
+}
+
+// build builds a management API client that authenticates with the client
+// secret or private key of the tenant, replacing the previous one
+func (a *tenantApi) build(tenant *tenant, credential []byte) error {
+	a.api = nil
+	a.tokenSource = nil
+	a.token = ""
+
+	// Token requests count towards the rate limit like any other request
+	tokenClient := &http.Client{Transport: ratelimit.Transport(nil), Timeout: tokenRequestTimeout}
+
+	if tenant.privateKey {
+		tokenSource, err := newPrivateKeyJwtTokenSource(tenant.domain, tenant.clientId, credential, tokenClient)
+
+		if err != nil {
+			return err
+		}
+
+		a.tokenSource = tokenSource
+		return nil
+	}
+
+	// The token source outlives the reconcile, so it mustn't use its context
+	tokenCtx := context.WithValue(context.Background(), oauth2.HTTPClient, tokenClient)
+
+	api, err := management.New(
+		tenant.domain,
+		management.WithClientCredentials(tokenCtx, tenant.clientId, string(credential)),
+		management.WithClient(&http.Client{Transport: ratelimit.Transport(nil)}),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	a.api = api
+	return nil
+}
+
+// refresh rebuilds the management API client of a tenant authenticated with
+// private_key_jwt if its token has changed. go-auth0 only gets management API
+// tokens itself with a client secret, otherwise it takes a static token
+func (a *tenantApi) refresh(tenant *tenant) error {
+	token, err := a.tokenSource.Token()
+
+	if err != nil {
+		return err
+	}
+
+	if a.api != nil && token.AccessToken == a.token {
+		return nil
+	}
+
+	api, err := management.New(
+		tenant.domain,
+		management.WithStaticToken(token.AccessToken),
+		management.WithClient(&http.Client{Transport: ratelimit.Transport(nil)}),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	a.api = api
+	a.token = token.AccessToken
+	return nil
+}
+
+// tenantIndex indexes resources by the key of the tenant they're managed in
+const tenantIndex = ".spec.tenantRef"
+
+// key returns the key of the tenant referenced by a resource in the
+// namespace, or of the default tenant if ref is nil, without resolving it
+func (t *Tenants) key(namespace string, ref *auth0v1alpha1.TenantReference) string {
+	if ref != nil && ref.Kind == auth0v1alpha1.NamespacedAuth0TenantKind {
+		return namespacedTenantKey(namespace, ref.Name)
+	}
+
+	if ref != nil {
+		return clusterTenantKey(ref.Name)
+	}
+
+	if t.defaultTenant == "" && t.environmentTenant != nil {
+		return t.environmentTenant.key
+	}
+
+	return clusterTenantKey(t.defaultTenant)
+}
+
+// keysForSecret returns the keys of the tenants whose credentials are held
+// in the secret
+func (t *Tenants) keysForSecret(ctx context.Context, secret types.NamespacedName) ([]string, error) {
+	var keys []string
+
+	clusterTenants := &auth0v1alpha1.Auth0TenantList{}
+	if err := t.client.List(ctx, clusterTenants); err != nil {
+		return nil, err
+	}
+
+	for _, tenant := range clusterTenants.Items {
+		for _, ref := range []*auth0v1alpha1.NamespacedSecretRef{tenant.Spec.ClientSecretRef, tenant.Spec.PrivateKeyRef} {
+			if ref != nil && ref.Namespace == secret.Namespace && ref.Name == secret.Name {
+				keys = append(keys, clusterTenantKey(tenant.Name))
+				break
+			}
+		}
+	}
+
+	namespacedTenants := &auth0v1alpha1.NamespacedAuth0TenantList{}
+	if err := t.client.List(ctx, namespacedTenants, client.InNamespace(secret.Namespace)); err != nil {
+		return nil, err
+	}
+
+	for _, tenant := range namespacedTenants.Items {
+		for _, ref := range []*auth0v1alpha1.SecretRef{tenant.Spec.ClientSecretRef, tenant.Spec.PrivateKeyRef} {
+			if ref != nil && ref.Name == secret.Name {
+				keys = append(keys, namespacedTenantKey(tenant.Namespace, tenant.Name))
+				break
+			}
+		}
+	}
+
+	return keys, nil
+}
+
+// indexByTenant indexes resources of a kind by the tenant they're managed
+// in, so they can be found when the credentials of the tenant change
+func indexByTenant(
+	mgr ctrl.Manager,
+	tenants *Tenants,
+	obj client.Object,
+	tenantRef func(client.Object) *auth0v1alpha1.TenantReference,
+) error {
+	return mgr.GetFieldIndexer().IndexField(
+		context.Background(),
+		obj,
+		tenantIndex,
+		func(obj client.Object) []string {
+			return []string{tenants.key(obj.GetNamespace(), tenantRef(obj))}
+		},
+	)
+}
+
+// requestsForTenantSecret returns requests for the resources in list that
+// are managed in the tenants whose credentials are held in secret. The kind
+// must be indexed by indexByTenant
+func requestsForTenantSecret(
+	ctx context.Context,
+	c client.Client,
+	tenants *Tenants,
+	list client.ObjectList,
+	secret client.Object,
+) []reconcile.Request {
+	logger := log.FromContext(ctx)
+
+	keys, err := tenants.keysForSecret(ctx, client.ObjectKeyFromObject(secret))
+
+	if err != nil {
+		logger.Error(err, "unable to list tenants")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, key := range keys {
+		if err := c.List(ctx, list, client.MatchingFields{tenantIndex: key}); err != nil {
+			logger.Error(err, "unable to list resources of tenant", "tenant", key)
+			return nil
+		}
+
+		objs, err := meta.ExtractList(list)
+
+		if err != nil {
+			logger.Error(err, "unable to list resources of tenant", "tenant", key)
+			return nil
+		}
+
+		for _, obj := range objs {
+			o := obj.(client.Object)
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Namespace: o.GetNamespace(),
+					Name:      o.GetName(),
+				},
+			})
+		}
+	}
+
+	return requests
+}
+
+// checkSameTenant returns an error if what is referenced by a resource in
+// the namespace is managed in a different Auth0 tenant. References are to
+// resources in the same namespace. Tenants are compared by domain, as
+// several can refer to the same Auth0 tenant
+func (t *Tenants) checkSameTenant(
+	ctx context.Context,
+	namespace string,
+	ref *auth0v1alpha1.TenantReference,
+	referencedRef *auth0v1alpha1.TenantReference,
+	what string,
+) error {
+	if t.key(namespace, ref) == t.key(namespace, referencedRef) {
+		return nil
+	}
+
+	domain, err := t.Domain(ctx, namespace, ref)
+
+	if err != nil {
+		return err
+	}
+
+	referencedDomain, err := t.Domain(ctx, namespace, referencedRef)
+
+	if err != nil {
+		return err
+	}
+
+	if domain != referencedDomain {
+		return fmt.Errorf("%s is managed in tenant %s rather than %s", what, referencedDomain, domain)
+	}
+
+	return nil
+}
+
+// clusterTenantKey returns the key of an Auth0Tenant
+func clusterTenantKey(name string) string {
+	return auth0v1alpha1.Auth0TenantKind + "/" + name
+}
+
+// namespacedTenantKey returns the key of a NamespacedAuth0Tenant
+func namespacedTenantKey(namespace string, name string) string {
+	return auth0v1alpha1.NamespacedAuth0TenantKind + "/" + namespace + "/" + name
+}
+
+// Domain returns the domain of the tenant referenced by a resource in the
+// namespace, without a scheme, e.g. example.eu.auth0.com
+func (t *Tenants) Domain(
+	ctx context.Context,
+	namespace string,
+	ref *auth0v1alpha1.TenantReference,
+) (string, error) {
+	tenant, err := t.resolve(ctx, namespace, ref)
+
+	if err != nil {
+		return "", err
+	}
+
+	domain := tenant.domain
+	if i := strings.Index(domain, "//"); i != -1 {
+		domain = domain[i+2:]
+	}
+
+	return strings.TrimSuffix(domain, "/"), nil
+}
+
+// ClientId returns the ID of the client the operator authenticates to the
+// tenant referenced by a resource in the namespace with
+func (t *Tenants) ClientId(
+	ctx context.Context,
+	namespace string,
+	ref *auth0v1alpha1.TenantReference,
+) (string, error) {
+	tenant, err := t.resolve(ctx, namespace, ref)
+
+	if err != nil {
+		return "", err
+	}
+
+	return tenant.clientId, nil
+}
+
+// resolve returns the tenant referenced by a resource in the namespace
+func (t *Tenants) resolve(
+	ctx context.Context,
+	namespace string,
+	ref *auth0v1alpha1.TenantReference,
+) (*tenant, error) {
+	if ref != nil && ref.Kind == auth0v1alpha1.NamespacedAuth0TenantKind {
+		return t.namespacedTenant(ctx, namespace, ref.Name)
+	}
+
+	name := t.defaultTenant
+	if ref != nil {
+		name = ref.Name
+	}
+
+	if name == "" && t.environmentTenant != nil {
+		return t.environmentTenant, nil
+	}
+
+	if name == "" {
+		return nil, &unusableTenantError{"no tenantRef set and no default tenant configured"}
+	}
+
+	return t.clusterTenant(ctx, namespace, name)
+}
+
+// clusterTenant returns the Auth0Tenant, provided resources in the
+// namespace are allowed to use it
+func (t *Tenants) clusterTenant(ctx context.Context, namespace string, name string) (*tenant, error) {
+	instance := &auth0v1alpha1.Auth0Tenant{}
+	if err := t.client.Get(ctx, client.ObjectKey{Name: name}, instance); err != nil {
+		return nil, fmt.Errorf("unable to get tenant %s: %w", name, err)
+	}
+
+	if !instance.AllowsNamespace(namespace) {
+		return nil, &unusableTenantError{fmt.Sprintf(
+			"tenant %s can't be used by resources in namespace %s as it isn't in its allowedNamespaces",
+			name,
+			namespace,
+		)}
+	}
+
+	resolved := &tenant{
+		key:            clusterTenantKey(name),
+		generation:     instance.Generation,
+		domain:         instance.Spec.Domain,
+		clientId:       instance.Spec.ClientId,
+		privateKeyFile: instance.Spec.PrivateKeyFile,
+		privateKey:     instance.Spec.ClientSecretRef == nil,
+	}
+
+	ref := instance.Spec.ClientSecretRef
+	if ref == nil {
+		ref = instance.Spec.PrivateKeyRef
+	}
+
+	if ref != nil {
+		resolved.secretRef = types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}
+		resolved.secretKey = ref.Key
+	}
+
+	return resolved, nil
+}
+
+// namespacedTenant returns the NamespacedAuth0Tenant in the namespace
+func (t *Tenants) namespacedTenant(ctx context.Context, namespace string, name string) (*tenant, error) {
+	instance := &auth0v1alpha1.NamespacedAuth0Tenant{}
+	if err := t.client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, instance); err != nil {
+		return nil, fmt.Errorf("unable to get namespaced tenant %s/%s: %w", namespace, name, err)
+	}
+
+	ref := instance.Spec.ClientSecretRef
+	if ref == nil {
+		ref = instance.Spec.PrivateKeyRef
+	}
+
+	if ref == nil {
+		return nil, &unusableTenantError{fmt.Sprintf("namespaced tenant %s/%s has no credentials", namespace, name)}
+	}
+
+	return &tenant{
+		key:        namespacedTenantKey(namespace, name),
+		generation: instance.Generation,
+		domain:     instance.Spec.Domain,
+		clientId:   instance.Spec.ClientId,
+		secretRef:  types.NamespacedName{Namespace: namespace, Name: ref.Name},
+		secretKey:  ref.Key,
+		privateKey: instance.Spec.ClientSecretRef == nil,
+	}, nil
+}
@@ -0,0 +1,39 @@
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Tenants", func() {
+	When("no default tenant is set but the environment tenant is", func() {
+		var tenants *Tenants
+
+		BeforeEach(func() {
+			tenants = NewTenants(k8sClient, "")
+			tenants.UseEnvironmentTenant(
+				mustGetEnv("AUTH0_DOMAIN"),
+				mustGetEnv("AUTH0_CLIENT_ID"),
+				mustGetEnv("AUTH0_CLIENT_SECRET"),
+			)
+		})
+
+		It("should manage resources without a tenantRef in it from any namespace", func() {
+			api, err := tenants.Api(ctx, "any-namespace", nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(api).ToNot(BeNil())
+
+			clientId, err := tenants.ClientId(ctx, "any-namespace", nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(clientId).To(Equal(mustGetEnv("AUTH0_CLIENT_ID")))
+		})
+	})
+
+	When("neither a default tenant nor the environment tenant is set", func() {
+		It("should refuse resources without a tenantRef as the tenant can't be used", func() {
+			_, err := NewTenants(k8sClient, "").Api(ctx, "default", nil)
+			Expect(err).To(HaveOccurred())
+			Expect(isTenantUnusable(err)).To(BeTrue())
+		})
+	})
+})
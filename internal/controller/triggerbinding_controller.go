@@ -0,0 +1,386 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/auth0/go-auth0"
+	"github.com/auth0/go-auth0/management"
+	auth0v1alpha1 "github.com/rgracey/auth0-operator/api/v1alpha1"
+)
+
+// triggerBindingTriggerIndex indexes TriggerBindings by the trigger they bind
+// actions to
+const triggerBindingTriggerIndex = ".spec.trigger"
+
+// TriggerBindingReconciler reconciles a TriggerBinding object
+type TriggerBindingReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	Tenants  *Tenants
+}
+
+//+kubebuilder:rbac:groups=auth0.gracey.io,resources=triggerbindings,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=auth0.gracey.io,resources=triggerbindings/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=auth0.gracey.io,resources=triggerbindings/finalizers,verbs=update
+
+// Reconcile moves the Auth0 bindings of a trigger towards the state
+// specified by the TriggerBinding object
+func (r *TriggerBindingReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	// Fetch the TriggerBinding instance
+	instance := &auth0v1alpha1.TriggerBinding{}
+	if err := r.Get(ctx, req.NamespacedName, instance); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	// Check if the TriggerBinding instance is being deleted, and run finalizer logic.
+	// This comes before resolving the tenant so that deletion isn't blocked
+	// by a tenant that can no longer be used
+	if instance.IsBeingDeleted() {
+		logger.Info("deleting trigger bindings", "trigger", instance.Spec.Trigger)
+		return ctrl.Result{}, r.handleFinalizer(ctx, instance)
+	}
+
+	// The TriggerBinding is managed in the Auth0 tenant it references. A finalizer
+	// isn't added until the tenant can be used to clean up after it
+	api, err := r.Tenants.Api(ctx, instance.Namespace, instance.Spec.TenantRef)
+
+	if err != nil {
+		logger.Error(err, "unable to get Auth0 tenant")
+		return ctrl.Result{}, err
+	}
+
+	if !hasFinalizer(instance) {
+		return ctrl.Result{Requeue: true}, addFinalizer(ctx, r.Client, instance)
+	}
+
+	// Auth0 has a single set of bindings per trigger, so only the first
+	// TriggerBinding for a trigger of a tenant manages them
+	duplicateOf, err := r.duplicateOf(ctx, instance)
+
+	if err != nil {
+		logger.Error(err, "unable to check for duplicate trigger bindings", "trigger", instance.Spec.Trigger)
+		return ctrl.Result{}, err
+	}
+
+	if duplicateOf != nil {
+		message := fmt.Sprintf(
+			"Bindings of %s are already managed by %s/%s",
+			instance.Spec.Trigger,
+			duplicateOf.Namespace,
+			duplicateOf.Name,
+		)
+
+		logger.Info("duplicate trigger binding", "trigger", instance.Spec.Trigger, "managedBy", duplicateOf.Name)
+		r.Recorder.Event(instance, "Warning", EventReasonDuplicate, message)
+
+		// Deleting the duplicate mustn't remove the bindings it doesn't manage
+		if len(instance.Status.BoundActions) > 0 {
+			instance.Status.BoundActions = nil
+			return ctrl.Result{}, r.Status().Update(ctx, instance)
+		}
+
+		return ctrl.Result{}, nil
+	}
+
+	bindings := make([]*management.ActionBinding, 0, len(instance.Spec.Actions))
+	actionIDs := make([]string, 0, len(instance.Spec.Actions))
+
+	for _, a := range instance.Spec.Actions {
+		id, err := resolveActionReference(
+			ctx,
+			r.Client,
+			r.Tenants,
+			instance.Namespace,
+			instance.Spec.TenantRef,
+			a.ActionRef,
+		)
+
+		if err != nil {
+			logger.Error(err, "unable to resolve action", "action", a.ActionRef.Name)
+			r.Recorder.Event(instance, "Warning", EventReasonUpdateFailed, err.Error())
+			return ctrl.Result{}, err
+		}
+
+		// Binding only some of the actions would change the order they're
+		// executed in, so the bindings are reconciled again once all
+		// actions have been deployed
+		if id == "" {
+			logger.Info("waiting for action to be deployed", "action", a.ActionRef.Name)
+			return ctrl.Result{}, nil
+		}
+
+		bindings = append(bindings, &management.ActionBinding{
+			Ref: &management.ActionBindingReference{
+				Type:  auth0.String(management.ActionBindingReferenceByID),
+				Value: auth0.String(id),
+			},
+			DisplayName: stringOrNil(a.DisplayName),
+		})
+		actionIDs = append(actionIDs, id)
+	}
+
+	current, err := api.Action.Bindings(ctx, instance.Spec.Trigger)
+
+	if err != nil {
+		logger.Error(err, "unable to fetch trigger bindings", "trigger", instance.Spec.Trigger)
+		r.Recorder.Event(instance, "Warning", EventReasonUpdateFailed, err.Error())
+		return ctrl.Result{}, err
+	}
+
+	if bindingsUpToDate(current.Bindings, bindings, actionIDs) {
+		// The bindings may have been made before the TriggerBinding managed
+		// them, e.g. by a previous TriggerBinding for the trigger
+		if !equalStrings(instance.Status.BoundActions, actionIDs) {
+			instance.Status.BoundActions = actionIDs
+			return ctrl.Result{}, r.Status().Update(ctx, instance)
+		}
+
+		return ctrl.Result{}, nil
+	}
+
+	logger.Info("updating trigger bindings", "trigger", instance.Spec.Trigger)
+	err = api.Action.UpdateBindings(ctx, instance.Spec.Trigger, bindings)
+
+	if err != nil {
+		logger.Error(err, "unable to update trigger bindings", "trigger", instance.Spec.Trigger)
+		r.Recorder.Event(instance, "Warning", EventReasonUpdateFailed, err.Error())
+		return ctrl.Result{}, err
+	}
+
+	instance.Status.BoundActions = actionIDs
+	if err := r.Status().Update(ctx, instance); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	r.Recorder.Event(
+		instance,
+		"Normal",
+		EventReasonUpdated,
+		fmt.Sprintf(
+			"Bound actions to %s: %s",
+			instance.Spec.Trigger,
+			strings.Join(actionIDs, ", "),
+		),
+	)
+
+	return ctrl.Result{}, nil
+}
+
+// duplicateOf returns the TriggerBinding that manages the bindings of the
+// same trigger in the same tenant, if it isn't this one. The oldest
+// TriggerBinding manages the bindings
+func (r *TriggerBindingReconciler) duplicateOf(
+	ctx context.Context,
+	instance *auth0v1alpha1.TriggerBinding,
+) (*auth0v1alpha1.TriggerBinding, error) {
+	triggerBindings := &auth0v1alpha1.TriggerBindingList{}
+	err := r.List(
+		ctx,
+		triggerBindings,
+		client.MatchingFields{triggerBindingTriggerIndex: instance.Spec.Trigger},
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	domain, err := r.Tenants.Domain(ctx, instance.Namespace, instance.Spec.TenantRef)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var first *auth0v1alpha1.TriggerBinding
+	for i := range triggerBindings.Items {
+		b := &triggerBindings.Items[i]
+
+		if b.UID == instance.UID || b.IsBeingDeleted() || !createdBefore(b, instance) {
+			continue
+		}
+
+		// Tenants are compared by domain, as several can refer to the same
+		// Auth0 tenant
+		if d, err := r.Tenants.Domain(ctx, b.Namespace, b.Spec.TenantRef); err != nil || d != domain {
+			continue
+		}
+
+		if first == nil || createdBefore(b, first) {
+			first = b
+		}
+	}
+
+	return first, nil
+}
+
+// createdBefore returns true if a was created before b, ordering
+// TriggerBindings created at the same time by namespace and name
+func createdBefore(a, b *auth0v1alpha1.TriggerBinding) bool {
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+
+	if a.Namespace != b.Namespace {
+		return a.Namespace < b.Namespace
+	}
+
+	return a.Name < b.Name
+}
+
+// bindingsUpToDate returns true if the current bindings of a trigger bind
+// the actions with the given IDs in order, with the desired display names
+func bindingsUpToDate(
+	current []*management.ActionBinding,
+	desired []*management.ActionBinding,
+	actionIDs []string,
+) bool {
+	if len(current) != len(desired) {
+		return false
+	}
+
+	for i, b := range current {
+		if b.GetAction().GetID() != actionIDs[i] {
+			return false
+		}
+
+		if desired[i].DisplayName != nil && b.GetDisplayName() != desired[i].GetDisplayName() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// triggerBindingsForAction maps an Action to the TriggerBindings that bind it
+func (r *TriggerBindingReconciler) triggerBindingsForAction(ctx context.Context, obj client.Object) []reconcile.Request {
+	triggerBindings := &auth0v1alpha1.TriggerBindingList{}
+	if err := r.List(ctx, triggerBindings, client.InNamespace(obj.GetNamespace())); err != nil {
+		log.FromContext(ctx).Error(err, "unable to list trigger bindings")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, b := range triggerBindings.Items {
+		for _, a := range b.Spec.Actions {
+			if a.ActionRef.Name == obj.GetName() {
+				requests = append(requests, reconcile.Request{
+					NamespacedName: types.NamespacedName{
+						Namespace: b.Namespace,
+						Name:      b.Name,
+					},
+				})
+				break
+			}
+		}
+	}
+
+	return requests
+}
+
+// triggerBindingsForTrigger maps a TriggerBinding to the other
+// TriggerBindings for its trigger, so a duplicate takes over managing the
+// bindings once the TriggerBinding managing them is deleted
+func (r *TriggerBindingReconciler) triggerBindingsForTrigger(ctx context.Context, obj client.Object) []reconcile.Request {
+	triggerBindings := &auth0v1alpha1.TriggerBindingList{}
+	err := r.List(
+		ctx,
+		triggerBindings,
+		client.MatchingFields{triggerBindingTriggerIndex: obj.(*auth0v1alpha1.TriggerBinding).Spec.Trigger},
+	)
+
+	if err != nil {
+		log.FromContext(ctx).Error(err, "unable to list trigger bindings")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, b := range triggerBindings.Items {
+		if b.UID != obj.GetUID() {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Namespace: b.Namespace,
+					Name:      b.Name,
+				},
+			})
+		}
+	}
+
+	return requests
+}
+
+// triggerBindingsForTenantSecret maps a Secret to the TriggerBindings managed
+// in the tenants whose credentials it holds
+func (r *TriggerBindingReconciler) triggerBindingsForTenantSecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	return requestsForTenantSecret(ctx, r.Client, r.Tenants, &auth0v1alpha1.TriggerBindingList{}, obj)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *TriggerBindingReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Index TriggerBindings by their trigger, so duplicates can be found
+	err := mgr.GetFieldIndexer().IndexField(
+		context.Background(),
+		&auth0v1alpha1.TriggerBinding{},
+		triggerBindingTriggerIndex,
+		func(obj client.Object) []string {
+			return []string{obj.(*auth0v1alpha1.TriggerBinding).Spec.Trigger}
+		},
+	)
+
+	if err != nil {
+		return err
+	}
+
+	err = indexByTenant(mgr, r.Tenants, &auth0v1alpha1.TriggerBinding{}, func(obj client.Object) *auth0v1alpha1.TenantReference {
+		return obj.(*auth0v1alpha1.TriggerBinding).Spec.TenantRef
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&auth0v1alpha1.TriggerBinding{}).
+		Watches(
+			&auth0v1alpha1.Action{},
+			handler.EnqueueRequestsFromMapFunc(r.triggerBindingsForAction),
+		).
+		Watches(
+			&auth0v1alpha1.TriggerBinding{},
+			handler.EnqueueRequestsFromMapFunc(r.triggerBindingsForTrigger),
+		).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.triggerBindingsForTenantSecret),
+		).
+		Complete(rateLimitAware(r))
+}
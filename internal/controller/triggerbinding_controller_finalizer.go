@@ -0,0 +1,49 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/auth0/go-auth0/management"
+	auth0v1alpha1 "github.com/rgracey/auth0-operator/api/v1alpha1"
+)
+
+// handleFinalizer handles the finalizer logic for the TriggerBinding
+func (r *TriggerBindingReconciler) handleFinalizer(
+	ctx context.Context,
+	instance *auth0v1alpha1.TriggerBinding,
+) error {
+	if !hasFinalizer(instance) {
+		return nil
+	}
+
+	if len(instance.Status.BoundActions) == 0 {
+		return removeFinalizer(ctx, r.Client, instance)
+	}
+
+	api, err := deletionApi(ctx, r.Tenants, r.Recorder, instance, instance.Spec.TenantRef)
+	if err != nil {
+		return err
+	}
+
+	if api == nil {
+		return removeFinalizer(ctx, r.Client, instance)
+	}
+
+	// Unbind all actions from the trigger
+	err = api.Action.UpdateBindings(ctx, instance.Spec.Trigger, []*management.ActionBinding{})
+
+	if err != nil {
+		r.Recorder.Event(instance, "Warning", EventReasonDeleteFailed, err.Error())
+		return err
+	}
+
+	r.Recorder.Event(
+		instance,
+		"Normal",
+		EventReasonDeleted,
+		fmt.Sprintf("Removed all bindings from %s", instance.Spec.Trigger),
+	)
+
+	return removeFinalizer(ctx, r.Client, instance)
+}
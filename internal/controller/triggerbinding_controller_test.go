@@ -0,0 +1,154 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	auth0v1alpha1 "github.com/rgracey/auth0-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("TriggerBinding controller", func() {
+	var key types.NamespacedName
+
+	var configMap *corev1.ConfigMap
+
+	var action *auth0v1alpha1.Action
+
+	var triggerBinding *auth0v1alpha1.TriggerBinding
+
+	BeforeEach(func() {
+		suffix := time.Now().Format("20060102150405")
+
+		key = types.NamespacedName{
+			Name:      "test-trigger-binding-" + suffix,
+			Namespace: "default",
+		}
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+			},
+			Data: map[string]string{
+				"index.js": "exports.onExecuteCredentialsExchange = async (event, api) => {};\n",
+			},
+		}
+		action = &auth0v1alpha1.Action{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+			},
+			Spec: auth0v1alpha1.ActionSpec{
+				Name: "test-suite-" + suffix,
+				Trigger: auth0v1alpha1.ActionTrigger{
+					Id: "credentials-exchange",
+				},
+				CodeRef: auth0v1alpha1.ConfigMapRef{
+					Name: configMap.Name,
+					Key:  "index.js",
+				},
+				Runtime: "node18",
+				Deploy:  true,
+			},
+		}
+		triggerBinding = &auth0v1alpha1.TriggerBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+			},
+			Spec: auth0v1alpha1.TriggerBindingSpec{
+				Trigger: "credentials-exchange",
+				Actions: []auth0v1alpha1.TriggerBindingAction{
+					{
+						ActionRef: auth0v1alpha1.ActionReference{
+							Name: action.Name,
+						},
+						DisplayName: "Test suite",
+					},
+				},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.Delete(context.Background(), triggerBinding)).To(Succeed())
+
+		Eventually(func() bool {
+			err := k8sClient.Get(ctx, key, &auth0v1alpha1.TriggerBinding{})
+			return ctrlclient.IgnoreNotFound(err) == nil
+		}).WithTimeout(timeout).Should(BeTrue())
+
+		Expect(k8sClient.Delete(context.Background(), action)).To(Succeed())
+		Expect(k8sClient.Delete(context.Background(), configMap)).To(Succeed())
+	})
+
+	Describe("when a trigger binding is created", func() {
+		JustBeforeEach(func() {
+			// Create the binding before the action, so it must wait for it to be deployed
+			Expect(k8sClient.Create(ctx, triggerBinding)).To(Succeed())
+			Expect(k8sClient.Create(ctx, configMap)).To(Succeed())
+			Expect(k8sClient.Create(ctx, action)).To(Succeed())
+
+			Eventually(func() []string {
+				if err := k8sClient.Get(ctx, key, triggerBinding); err != nil {
+					return nil
+				}
+				return triggerBinding.Status.BoundActions
+			}).WithTimeout(2 * time.Minute).WithPolling(time.Second).ShouldNot(BeEmpty())
+
+			Expect(k8sClient.Get(ctx, key, action)).To(Succeed())
+		})
+
+		It("should bind the action to the trigger in Auth0", func() {
+			bindings, err := auth0Api.Action.Bindings(ctx, "credentials-exchange")
+			Expect(err).To(BeNil())
+			Expect(bindings.Bindings).To(HaveLen(1))
+			Expect(bindings.Bindings[0].GetAction().GetID()).To(Equal(action.Status.Auth0Id))
+			Expect(bindings.Bindings[0].GetDisplayName()).To(Equal("Test suite"))
+		})
+
+		When("another trigger binding is created for the trigger", func() {
+			var duplicate *auth0v1alpha1.TriggerBinding
+
+			BeforeEach(func() {
+				duplicate = &auth0v1alpha1.TriggerBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      key.Name + "-duplicate",
+						Namespace: key.Namespace,
+					},
+					Spec: auth0v1alpha1.TriggerBindingSpec{
+						Trigger: "credentials-exchange",
+					},
+				}
+			})
+
+			It("should ignore the duplicate, even when it's deleted", func() {
+				Expect(k8sClient.Create(ctx, duplicate)).To(Succeed())
+
+				Consistently(func() (int, error) {
+					bindings, err := auth0Api.Action.Bindings(ctx, "credentials-exchange")
+					if err != nil {
+						return 0, err
+					}
+					return len(bindings.Bindings), nil
+				}).WithTimeout(5 * time.Second).WithPolling(time.Second).Should(Equal(1))
+
+				Expect(k8sClient.Delete(ctx, duplicate)).To(Succeed())
+
+				Eventually(func() bool {
+					err := k8sClient.Get(ctx, ctrlclient.ObjectKeyFromObject(duplicate), &auth0v1alpha1.TriggerBinding{})
+					return ctrlclient.IgnoreNotFound(err) == nil
+				}).WithTimeout(timeout).Should(BeTrue())
+
+				bindings, err := auth0Api.Action.Bindings(ctx, "credentials-exchange")
+				Expect(err).To(BeNil())
+				Expect(bindings.Bindings).To(HaveLen(1))
+			})
+		})
+	})
+})
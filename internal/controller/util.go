@@ -0,0 +1,111 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+
+	"github.com/auth0/go-auth0/management"
+)
+
+// stringOrNil returns a pointer to s, or nil if s is empty so that
+// the field is omitted from requests to Auth0
+func stringOrNil(s string) *string {
+	if s == "" {
+		return nil
+	}
+
+	return &s
+}
+
+// isNotFound returns true if err is a not found error from the Auth0 API
+func isNotFound(err error) bool {
+	var mErr management.Error
+	return errors.As(err, &mErr) && mErr.Status() == http.StatusNotFound
+}
+
+// isClientError returns true if err is an error from the Auth0 API caused
+// by the request, other than being rate limited
+func isClientError(err error) bool {
+	var mErr management.Error
+	return errors.As(err, &mErr) &&
+		mErr.Status() >= http.StatusBadRequest &&
+		mErr.Status() < http.StatusInternalServerError &&
+		mErr.Status() != http.StatusTooManyRequests
+}
+
+// stringSliceOrNil returns a pointer to s, or nil if s is empty so that
+// the field is omitted from requests to Auth0
+func stringSliceOrNil(s []string) *[]string {
+	if len(s) == 0 {
+		return nil
+	}
+
+	return &s
+}
+
+// needsUpdate returns true if a field set in desired has a different value
+// in current, comparing them as they're sent to Auth0. Fields omitted from
+// desired aren't managed so are ignored, while lists must match in full
+func needsUpdate(desired, current interface{}) bool {
+	d, err := toJSONValue(desired)
+	if err != nil {
+		return true
+	}
+
+	c, err := toJSONValue(current)
+	if err != nil {
+		return true
+	}
+
+	return !containsJSON(d, c)
+}
+
+// toJSONValue returns the generic JSON representation of v
+func toJSONValue(v interface{}) (interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var value interface{}
+	return value, json.Unmarshal(b, &value)
+}
+
+// containsJSON returns true if current holds every value set in desired.
+// Empty objects and lists match missing ones
+func containsJSON(desired, current interface{}) bool {
+	switch d := desired.(type) {
+	case map[string]interface{}:
+		c, _ := current.(map[string]interface{})
+		if c == nil && current != nil {
+			return false
+		}
+
+		for k, v := range d {
+			if !containsJSON(v, c[k]) {
+				return false
+			}
+		}
+
+		return true
+
+	case []interface{}:
+		c, _ := current.([]interface{})
+		if (c == nil && current != nil) || len(c) != len(d) {
+			return false
+		}
+
+		for i := range d {
+			if !containsJSON(d[i], c[i]) {
+				return false
+			}
+		}
+
+		return true
+
+	default:
+		return reflect.DeepEqual(desired, current)
+	}
+}
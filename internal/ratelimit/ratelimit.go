@@ -0,0 +1,153 @@
+// Package ratelimit limits the rate of requests made to the Auth0 management
+// API by the whole process, and tracks when Auth0 rate limits reset
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/auth0/go-auth0/management"
+	"golang.org/x/time/rate"
+)
+
+// DefaultRetryAfter is how long to wait after being rate limited when Auth0
+// doesn't say when the rate limit resets
+const DefaultRetryAfter = 5 * time.Second
+
+// limiter is shared by every transport so that the rate limit applies to all
+// requests made by the process
+var limiter = &pausingLimiter{bucket: rate.NewLimiter(rate.Inf, 0)}
+
+// pausingLimiter is a token bucket that is paused until the Auth0 rate limit
+// resets whenever a request is rate limited
+type pausingLimiter struct {
+	bucket *rate.Limiter
+
+	mu      sync.Mutex
+	resetAt time.Time
+}
+
+// SetLimit sets the number of requests per second and the burst size of the
+// process wide rate limit. A limit of 0 disables the rate limit
+func SetLimit(limit float64, burst int) {
+	if limit <= 0 {
+		limiter.bucket.SetLimit(rate.Inf)
+		return
+	}
+
+	limiter.bucket.SetLimit(rate.Limit(limit))
+	limiter.bucket.SetBurst(burst)
+}
+
+// RetryAfter returns how long to wait before retrying if err is a rate limit
+// error from the Auth0 API
+func RetryAfter(err error) (time.Duration, bool) {
+	var mErr management.Error
+	if !errors.As(err, &mErr) || mErr.Status() != http.StatusTooManyRequests {
+		return 0, false
+	}
+
+	if d := limiter.resetAfter(time.Now()); d > 0 {
+		return d, true
+	}
+
+	return DefaultRetryAfter, true
+}
+
+// Transport returns an http.RoundTripper that waits for the process wide rate
+// limit before sending requests with base
+func Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &transport{base: base, limiter: limiter}
+}
+
+type transport struct {
+	base    http.RoundTripper
+	limiter *pausingLimiter
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.wait(req.Context()); err != nil {
+		return nil, err
+	}
+
+	res, err := t.base.RoundTrip(req)
+
+	if err == nil && res.StatusCode == http.StatusTooManyRequests {
+		t.limiter.pause(resetTime(res.Header, time.Now()))
+	}
+
+	return res, err
+}
+
+// wait blocks until the rate limit has reset and a token is available
+func (l *pausingLimiter) wait(ctx context.Context) error {
+	if d := l.resetAfter(time.Now()); d > 0 {
+		timer := time.NewTimer(d)
+		defer timer.Stop()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	return l.bucket.Wait(ctx)
+}
+
+// pause stops requests from being sent until resetAt
+func (l *pausingLimiter) pause(resetAt time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if resetAt.After(l.resetAt) {
+		l.resetAt = resetAt
+	}
+}
+
+// resetAfter returns how long until the rate limit resets
+func (l *pausingLimiter) resetAfter(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.resetAt.Sub(now)
+}
+
+// resetTime returns when the rate limit resets according to the
+// X-RateLimit-Reset and Retry-After headers, whichever is later
+func resetTime(header http.Header, now time.Time) time.Time {
+	resetAt := now.Add(DefaultRetryAfter)
+	found := false
+
+	later := func(t time.Time) {
+		if !found || t.After(resetAt) {
+			resetAt = t
+			found = true
+		}
+	}
+
+	// The unix time in seconds that the rate limit resets at
+	if s, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		later(time.Unix(s, 0))
+	}
+
+	// Either a number of seconds or an HTTP date
+	if retryAfter := header.Get("Retry-After"); retryAfter != "" {
+		if s, err := strconv.Atoi(retryAfter); err == nil {
+			later(now.Add(time.Duration(s) * time.Second))
+		} else if t, err := http.ParseTime(retryAfter); err == nil {
+			later(t)
+		}
+	}
+
+	return resetAt
+}
@@ -0,0 +1,173 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"golang.org/x/time/rate"
+)
+
+// statusError is a management.Error with the given status
+type statusError int
+
+func (e statusError) Status() int {
+	return int(e)
+}
+
+func (e statusError) Error() string {
+	return fmt.Sprintf("%d", int(e))
+}
+
+var _ = Describe("Rate limit", func() {
+	now := time.Unix(1700000000, 0)
+
+	DescribeTable("resetTime",
+		func(headers map[string]string, expected time.Time) {
+			header := http.Header{}
+			for k, v := range headers {
+				header.Set(k, v)
+			}
+
+			Expect(resetTime(header, now)).To(BeTemporally("==", expected))
+		},
+		Entry("without headers", map[string]string{}, now.Add(DefaultRetryAfter)),
+		Entry("with X-RateLimit-Reset",
+			map[string]string{"X-RateLimit-Reset": "1700000030"},
+			now.Add(30*time.Second),
+		),
+		Entry("with an invalid X-RateLimit-Reset",
+			map[string]string{"X-RateLimit-Reset": "soon"},
+			now.Add(DefaultRetryAfter),
+		),
+		Entry("with Retry-After in seconds",
+			map[string]string{"Retry-After": "12"},
+			now.Add(12*time.Second),
+		),
+		Entry("with Retry-After as a date",
+			map[string]string{"Retry-After": now.Add(20 * time.Second).UTC().Format(http.TimeFormat)},
+			now.Add(20*time.Second),
+		),
+		Entry("with an invalid Retry-After",
+			map[string]string{"Retry-After": "later"},
+			now.Add(DefaultRetryAfter),
+		),
+		Entry("with both, using the later X-RateLimit-Reset",
+			map[string]string{"X-RateLimit-Reset": "1700000030", "Retry-After": "12"},
+			now.Add(30*time.Second),
+		),
+		Entry("with both, using the later Retry-After",
+			map[string]string{"X-RateLimit-Reset": "1700000010", "Retry-After": "12"},
+			now.Add(12*time.Second),
+		),
+		Entry("with a reset sooner than the default",
+			map[string]string{"Retry-After": "1"},
+			now.Add(time.Second),
+		),
+	)
+
+	Describe("RetryAfter", func() {
+		AfterEach(func() {
+			limiter.mu.Lock()
+			limiter.resetAt = time.Time{}
+			limiter.mu.Unlock()
+		})
+
+		DescribeTable("errors that aren't rate limits",
+			func(err error) {
+				_, ok := RetryAfter(err)
+				Expect(ok).To(BeFalse())
+			},
+			Entry("nil", nil),
+			Entry("a plain error", errors.New("failed")),
+			Entry("a not found error", statusError(http.StatusNotFound)),
+		)
+
+		It("should wait the default time if the reset isn't known", func() {
+			d, ok := RetryAfter(statusError(http.StatusTooManyRequests))
+			Expect(ok).To(BeTrue())
+			Expect(d).To(Equal(DefaultRetryAfter))
+		})
+
+		It("should wait until the rate limit resets", func() {
+			limiter.pause(time.Now().Add(time.Minute))
+
+			d, ok := RetryAfter(fmt.Errorf("wrapped: %w", statusError(http.StatusTooManyRequests)))
+			Expect(ok).To(BeTrue())
+			Expect(d).To(BeNumerically("~", time.Minute, time.Second))
+		})
+	})
+
+	Describe("pausingLimiter", func() {
+		var l *pausingLimiter
+
+		BeforeEach(func() {
+			l = &pausingLimiter{bucket: rate.NewLimiter(rate.Inf, 0)}
+		})
+
+		It("should only extend the pause", func() {
+			l.pause(now.Add(time.Minute))
+			l.pause(now.Add(time.Second))
+
+			Expect(l.resetAfter(now)).To(Equal(time.Minute))
+		})
+
+		It("shouldn't wait if it isn't paused", func() {
+			start := time.Now()
+			Expect(l.wait(context.Background())).To(Succeed())
+			Expect(time.Since(start)).To(BeNumerically("<", 50*time.Millisecond))
+		})
+
+		It("should wait until the pause ends", func() {
+			l.pause(time.Now().Add(200 * time.Millisecond))
+
+			start := time.Now()
+			Expect(l.wait(context.Background())).To(Succeed())
+			Expect(time.Since(start)).To(BeNumerically(">=", 150*time.Millisecond))
+		})
+
+		It("should stop waiting when the context is done", func() {
+			l.pause(time.Now().Add(time.Minute))
+
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+
+			Expect(l.wait(ctx)).To(MatchError(context.DeadlineExceeded))
+		})
+	})
+
+	Describe("Transport", func() {
+		AfterEach(func() {
+			limiter.mu.Lock()
+			limiter.resetAt = time.Time{}
+			limiter.mu.Unlock()
+		})
+
+		It("should pause the limiter when rate limited", func() {
+			base := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				header := http.Header{}
+				header.Set("Retry-After", "30")
+				return &http.Response{StatusCode: http.StatusTooManyRequests, Header: header}, nil
+			})
+
+			req, err := http.NewRequest(http.MethodGet, "https://example.com", nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = Transport(base).RoundTrip(req)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(limiter.resetAfter(time.Now())).To(BeNumerically("~", 30*time.Second, time.Second))
+		})
+	})
+})
+
+// roundTripperFunc is an http.RoundTripper implemented by a function
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip implements http.RoundTripper
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}